@@ -0,0 +1,112 @@
+package bliss
+
+import (
+	"fmt"
+	"golang.org/x/crypto/sha3"
+	"runtime"
+	"sync"
+)
+
+// A BatchItem is a triple of public key, message and signature to be verified
+// by VerifyBatch.
+type BatchItem struct {
+	PublicKey *BlissPublicKey
+	Msg       []byte
+	Sig       *BlissSignature
+}
+
+// A BatchResult is the outcome of verifying a BatchItem. Valid is true only
+// if Failure is VerifyOK and Err is nil. Failure tells which check of the
+// verification algorithm rejected the signature, and Err is set when the
+// item is malformed as in BlissPublicKey.Verify, e.g. for a missing key or
+// signature, or a signature of a different version.
+type BatchResult struct {
+	Valid   bool
+	Failure VerifyFailure
+	Err     error
+}
+
+// Verify many signatures with a pool of workers goroutines. If workers is not
+// positive, runtime.NumCPU() workers are used.
+// Each distinct public key, as told by its fingerprint, is prepared only
+// once, and the prepared key is shared by all the items with an equal key.
+// The i'th result is the outcome of the i'th item.
+func VerifyBatch(items []BatchItem, workers int) []BatchResult {
+	results := make([]BatchResult, len(items))
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > len(items) {
+		workers = len(items)
+	}
+
+	prepared, failed := prepareBatch(items)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				item := &items[i]
+				if item.PublicKey == nil || item.Sig == nil {
+					results[i].Err = fmt.Errorf("Missing public key or signature in item %d: %w",
+						i, ErrInvalidSignature)
+					continue
+				}
+				if failed[i] != nil {
+					results[i].Err = failed[i]
+					continue
+				}
+				hash := sha3.Sum512(item.Msg)
+				failure, err := prepared[i].check(hash[:], item.Sig)
+				if err == nil && failure == VerifyVersionMismatch {
+					err = failure.Err()
+				}
+				results[i].Failure = failure
+				results[i].Err = err
+				results[i].Valid = failure == VerifyOK && err == nil
+			}
+		}()
+	}
+	for i := range items {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+// Prepare the public keys of the items for VerifyBatch. Equal keys are
+// recognized by their fingerprints, so the keys deserialized separately from
+// the same bytes share a prepared key too. The i'th prepared key, or the
+// error preparing it, is for the i'th item, and both are nil if the item has
+// no key.
+func prepareBatch(items []BatchItem) ([]*PreparedPublicKey, []error) {
+	byFingerprint := make(map[string]*PreparedPublicKey)
+	prepared := make([]*PreparedPublicKey, len(items))
+	failed := make([]error, len(items))
+	for i := range items {
+		key := items[i].PublicKey
+		if key == nil {
+			continue
+		}
+		fingerprint, err := key.Fingerprint()
+		if err != nil {
+			failed[i] = err
+			continue
+		}
+		p, ok := byFingerprint[string(fingerprint)]
+		if !ok {
+			p, err = key.Prepare()
+			if err != nil {
+				failed[i] = err
+				continue
+			}
+			byFingerprint[string(fingerprint)] = p
+		}
+		prepared[i] = p
+	}
+	return prepared, failed
+}
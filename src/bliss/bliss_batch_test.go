@@ -0,0 +1,136 @@
+package bliss
+
+import (
+	"errors"
+	"sampler"
+	"testing"
+)
+
+func TestVerifyBatch(t *testing.T) {
+	items := []BatchItem{}
+	expected := []VerifyFailure{}
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+		}
+		pub := key.PublicKey()
+		for j := 0; j < 4; j++ {
+			msg := []byte{byte(i), byte(j)}
+			sig, err := key.Sign(msg, entropy)
+			if err != nil {
+				t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			}
+			items = append(items, BatchItem{pub, msg, sig})
+			expected = append(expected, VerifyOK)
+		}
+		sig, err := key.Sign([]byte("Hello world"), entropy)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+		}
+		items = append(items, BatchItem{pub, []byte("Hello world!"), sig})
+		expected = append(expected, VerifyIndicesMismatch)
+
+		z1data := sig.z1.GetData()
+		saved := z1data[0]
+		z1data[0] = int32(key.Param().Binf) + 1
+		tmp := &BlissSignature{sig.z1.ScalarTimes(1), sig.z2, sig.c}
+		z1data[0] = saved
+		items = append(items, BatchItem{pub, []byte("Hello world"), tmp})
+		expected = append(expected, VerifyZ1MaxNorm)
+	}
+	items = append(items, BatchItem{items[0].PublicKey, items[0].Msg, items[len(items)-1].Sig})
+	expected = append(expected, VerifyVersionMismatch)
+
+	for _, workers := range []int{0, 1, 3, 100} {
+		results := VerifyBatch(items, workers)
+		if len(results) != len(items) {
+			t.Errorf("Wrong number of results: expect %d, got %d", len(items), len(results))
+			continue
+		}
+		for i := range results {
+			if expected[i] == VerifyVersionMismatch {
+				if !errors.Is(results[i].Err, ErrVersionMismatch) {
+					t.Errorf("Expect ErrVersionMismatch for item %d, got %v", i, results[i].Err)
+				}
+			} else if results[i].Err != nil {
+				t.Errorf("Error in verifying item %d: %s", i, results[i].Err.Error())
+			}
+			if results[i].Failure != expected[i] {
+				t.Errorf("Wrong failure for item %d: expect %s, got %s",
+					i, expected[i], results[i].Failure)
+			}
+			if results[i].Valid != (expected[i] == VerifyOK) {
+				t.Errorf("Wrong validity for item %d", i)
+			}
+		}
+	}
+
+	results := VerifyBatch([]BatchItem{{nil, nil, nil}}, 1)
+	if results[0].Valid || !errors.Is(results[0].Err, ErrInvalidSignature) {
+		t.Errorf("Item without key should not be verified")
+	}
+}
+
+func TestVerifyBatchSharesPreparedKeys(t *testing.T) {
+	seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+	entropy, err := sampler.NewEntropy(seed)
+	if err != nil {
+		t.Errorf("Error in initializing entropy: %s", err.Error())
+	}
+	key, err := GeneratePrivateKey(1, entropy)
+	if err != nil {
+		t.Errorf("Error in generating private key: %s", err.Error())
+		return
+	}
+	other, err := GeneratePrivateKey(1, entropy)
+	if err != nil {
+		t.Errorf("Error in generating private key: %s", err.Error())
+		return
+	}
+	enc, _ := key.PublicKey().Serialize()
+	items := []BatchItem{}
+	for i := 0; i < 3; i++ {
+		// Every item has its own copy of the same key.
+		pub, err := DeserializeBlissPublicKey(enc)
+		if err != nil {
+			t.Errorf("Error in decoding public key: %s", err.Error())
+			return
+		}
+		msg := []byte{byte(i)}
+		sig, err := key.Sign(msg, entropy)
+		if err != nil {
+			t.Errorf("Failed to generate signature: %s", err.Error())
+			return
+		}
+		items = append(items, BatchItem{pub, msg, sig})
+	}
+	items = append(items, BatchItem{other.PublicKey(), nil, nil})
+
+	prepared, failed := prepareBatch(items)
+	for i := range items {
+		if failed[i] != nil {
+			t.Errorf("Error in preparing item %d: %s", i, failed[i].Error())
+		}
+	}
+	if prepared[0] != prepared[1] || prepared[0] != prepared[2] {
+		t.Errorf("Equal public keys prepared more than once")
+	}
+	if prepared[0] == prepared[3] {
+		t.Errorf("Different public keys share a prepared key")
+	}
+	for i, result := range VerifyBatch(items[:3], 2) {
+		if !result.Valid {
+			t.Errorf("Item %d not verified: %s", i, result.Failure)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package bliss
+
+import (
+	cryptorand "crypto/rand"
+	"fmt"
+	"io"
+	"sampler"
+)
+
+// Read a seed of 64 bytes from rand and create an entropy from it.
+// If rand is nil, crypto/rand.Reader is used.
+func newEntropyFromReader(rand io.Reader) (*sampler.Entropy, error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+	seed := make([]byte, sampler.SHA_512_DIGEST_LENGTH)
+	defer wipeBytes(seed)
+	if _, err := io.ReadFull(rand, seed); err != nil {
+		return nil, fmt.Errorf("Failed to read random seed: %s", err.Error())
+	}
+	return sampler.NewEntropy(seed)
+}
+
+// Generate a BLISS key pair of the given version, and return the serialized
+// public key and private key. The randomness is a seed of 64 bytes read from
+// rand. If rand is nil, crypto/rand.Reader is used.
+func GenerateKey(rand io.Reader, version int) (pub, priv []byte, err error) {
+	entropy, err := newEntropyFromReader(rand)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer entropy.Destroy()
+	key, err := GeneratePrivateKey(version, entropy)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer key.Destroy()
+	pub, err = key.PublicKey().Serialize()
+	if err != nil {
+		return nil, nil, err
+	}
+	priv, err = key.Serialize()
+	if err != nil {
+		return nil, nil, err
+	}
+	return pub, priv, nil
+}
+
+// Sign the message with a serialized private key, and return the serialized
+// signature. The message is signed by SignHedged with crypto/rand.Reader.
+func Sign(priv, msg []byte) ([]byte, error) {
+	key, err := DeserializeBlissPrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	defer key.Destroy()
+	sig, err := key.SignHedged(msg, nil)
+	if err != nil {
+		return nil, err
+	}
+	return sig.Serialize()
+}
+
+// Report whether sig is a valid signature of msg under the serialized public
+// key pub. Malformed keys or signatures are reported as invalid, and so are
+// the non-canonical encodings of signatures, so that a valid signature has
+// only one encoding.
+func Verify(pub, msg, sig []byte) bool {
+	key, err := DeserializeBlissPublicKey(pub)
+	if err != nil {
+		return false
+	}
+	s, err := DeserializeBlissSignatureStrict(sig)
+	if err != nil {
+		return false
+	}
+	ok, err := key.Verify(msg, s)
+	return ok && err == nil
+}
@@ -0,0 +1,36 @@
+package bliss
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGenerateKeySignVerify(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		rand := bytes.NewReader(bytes.Repeat([]byte{byte(i)}, 64))
+		pub, priv, err := GenerateKey(rand, i)
+		if err != nil {
+			t.Errorf("Error in generating key for version %d: %s", i, err.Error())
+			continue
+		}
+		msg := []byte("Hello world")
+		sig, err := Sign(priv, msg)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		if !Verify(pub, msg, sig) {
+			t.Errorf("Failed to verify signature for version %d", i)
+		}
+		if Verify(pub, []byte("Hello world!"), sig) {
+			t.Errorf("Verified signature of wrong message for version %d", i)
+		}
+	}
+}
+
+func TestGenerateKeyShortRandom(t *testing.T) {
+	_, _, err := GenerateKey(bytes.NewReader(make([]byte, 10)), 0)
+	if err == nil {
+		t.Errorf("Generating key with insufficient randomness should fail")
+	}
+}
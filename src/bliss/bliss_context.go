@@ -0,0 +1,48 @@
+package bliss
+
+import (
+	"fmt"
+	"golang.org/x/crypto/sha3"
+	"sampler"
+)
+
+// The maximal length of a context string, so that its length fits in a byte.
+const MaxContextLength = 255
+
+// Compute the message hash fed into computeC for a message signed under the
+// context string ctx.
+// The input of computeC is SHA3-512(msg)||mode||len(ctx)||ctx||u, where mode
+// and len(ctx) take one byte each. The mode byte separates it from the pure
+// and the pre-hash modes, and the length prefix makes the boundary between
+// ctx and u unambiguous, so a signature is only valid under its own context.
+func contextHash(msg, ctx []byte) ([]byte, error) {
+	if len(ctx) > MaxContextLength {
+		return nil, fmt.Errorf("Context too long, expected <= %d, got %d",
+			MaxContextLength, len(ctx))
+	}
+	hash := sha3.Sum512(msg)
+	ret := append(hash[:], contextMode, byte(len(ctx)))
+	return append(ret, ctx...), nil
+}
+
+// The BLISS signature generation algorithm with domain separation. The
+// signature only verifies under the same context string ctx, e.g. the name
+// of the protocol that the signature is made for.
+func (key *BlissPrivateKey) SignWithContext(msg, ctx []byte, entropy *sampler.Entropy) (*BlissSignature, error) {
+	hash, err := contextHash(msg, ctx)
+	if err != nil {
+		return nil, err
+	}
+	return key.sign(hash, entropy)
+}
+
+// The BLISS signature verification algorithm with domain separation. The
+// signature must have been generated by SignWithContext with the same
+// context string ctx.
+func (key *BlissPublicKey) VerifyWithContext(msg, ctx []byte, sig *BlissSignature) (bool, error) {
+	hash, err := contextHash(msg, ctx)
+	if err != nil {
+		return false, err
+	}
+	return key.verify(hash, sig)
+}
@@ -0,0 +1,75 @@
+package bliss
+
+import (
+	"sampler"
+	"testing"
+)
+
+func TestSignVerifyWithContext(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+		}
+
+		pub := key.PublicKey()
+		msg := []byte("Hello world")
+		ctx := []byte("release-manifest-v1")
+		sig, err := key.SignWithContext(msg, ctx, entropy)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		err = verified(pub.VerifyWithContext(msg, ctx, sig))
+		if err != nil {
+			t.Errorf("Failed to verify signature for version %d: %s", i, err.Error())
+		}
+		err = verified(pub.VerifyWithContext(msg, []byte("release-manifest-v2"), sig))
+		if err == nil {
+			t.Errorf("Signature verified under another context for version %d", i)
+		}
+		err = verified(pub.Verify(msg, sig))
+		if err == nil {
+			t.Errorf("Signature with context verified in pure mode for version %d", i)
+		}
+
+		sig, err = key.SignWithContext(msg, nil, entropy)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		err = verified(pub.VerifyWithContext(msg, []byte{}, sig))
+		if err != nil {
+			t.Errorf("Failed to verify signature with empty context for version %d: %s", i, err.Error())
+		}
+		err = verified(pub.Verify(msg, sig))
+		if err == nil {
+			t.Errorf("Signature with empty context verified in pure mode for version %d", i)
+		}
+	}
+}
+
+func TestSignWithLongContext(t *testing.T) {
+	seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+	entropy, err := sampler.NewEntropy(seed)
+	if err != nil {
+		t.Errorf("Error in initializing entropy: %s", err.Error())
+	}
+	key, err := GeneratePrivateKey(0, entropy)
+	if err != nil {
+		t.Errorf("Error in generating private key: %s", err.Error())
+	}
+	_, err = key.SignWithContext([]byte("Hello world"), make([]byte, MaxContextLength+1), entropy)
+	if err == nil {
+		t.Errorf("Signing with too long context should fail")
+	}
+}
@@ -0,0 +1,94 @@
+package bliss
+
+import (
+	"crypto"
+	"golang.org/x/crypto/sha3"
+	"io"
+)
+
+// CryptoSigner adapts a BLISS private key to the crypto.Signer interface of
+// the standard library. BlissPrivateKey itself does not implement
+// crypto.Signer: its method Sign(msg, entropy) predates the interface and
+// would have to change its signature, breaking every existing caller. So the
+// private key only provides Public, and signing through crypto.Signer goes
+// by this wrapper.
+type CryptoSigner struct {
+	key *BlissPrivateKey
+}
+
+var _ crypto.Signer = (*CryptoSigner)(nil)
+
+// Create a crypto.Signer backed by the given BLISS private key.
+func NewCryptoSigner(key *BlissPrivateKey) *CryptoSigner {
+	return &CryptoSigner{key}
+}
+
+// Retrieve the BLISS public key corresponding to the wrapped private key.
+func (signer *CryptoSigner) Public() crypto.PublicKey {
+	return signer.key.Public()
+}
+
+// Destroy the wrapped private key as by BlissPrivateKey.Destroy. Sign fails
+// with ErrKeyDestroyed afterwards, while Public still works. It must not run
+// concurrently with Sign.
+func (signer *CryptoSigner) Destroy() {
+	signer.key.Destroy()
+}
+
+// Retrieve the BLISS public key as a crypto.PublicKey, the way the private
+// keys of the standard library do.
+func (privateKey *BlissPrivateKey) Public() crypto.PublicKey {
+	return privateKey.PublicKey()
+}
+
+// Sign the message with the wrapped private key, and return the serialized
+// signature. If opts.HashFunc() is zero, digest is the entire message, which
+// BLISS hashes by itself like ed25519. Otherwise digest is signed in pre-hash
+// mode as by SignDigest.
+// The signature is hedged as by SignHedged, with the randomness read from
+// rand.
+func (signer *CryptoSigner) Sign(rand io.Reader, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	h := crypto.Hash(0)
+	if opts != nil {
+		h = opts.HashFunc()
+	}
+	var hash []byte
+	if h == crypto.Hash(0) {
+		sum := sha3.Sum512(digest)
+		hash = sum[:]
+	} else {
+		var err error
+		hash, err = prehash(h, digest)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sig, err := signer.key.signHedged(hash, rand)
+	if err != nil {
+		return nil, err
+	}
+	return sig.Serialize()
+}
+
+// Report whether the given public key is a BLISS public key of the same
+// version and the same polynomial a.
+func (publicKey *BlissPublicKey) Equal(x crypto.PublicKey) bool {
+	other, ok := x.(*BlissPublicKey)
+	if !ok || other == nil {
+		return false
+	}
+	if publicKey.Param().Version != other.Param().Version {
+		return false
+	}
+	adata := publicKey.a.GetData()
+	bdata := other.a.GetData()
+	if len(adata) != len(bdata) {
+		return false
+	}
+	for i := 0; i < len(adata); i++ {
+		if adata[i] != bdata[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package bliss
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"sampler"
+	"testing"
+)
+
+func TestCryptoSigner(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+		}
+
+		var signer crypto.Signer = NewCryptoSigner(key)
+		pub, ok := signer.Public().(*BlissPublicKey)
+		if !ok || !pub.Equal(key.PublicKey()) {
+			t.Errorf("Wrong public key from signer for version %d", i)
+		}
+
+		msg := []byte("Hello world")
+		rand := bytes.NewReader(bytes.Repeat([]byte{7}, 64))
+		enc, err := signer.Sign(rand, msg, crypto.Hash(0))
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		sig, err := DeserializeBlissSignature(enc)
+		if err != nil {
+			t.Errorf("Error in decoding signature : %s", err.Error())
+			continue
+		}
+		err = verified(pub.Verify(msg, sig))
+		if err != nil {
+			t.Errorf("Failed to verify signature for version %d: %s", i, err.Error())
+		}
+
+		_, err = signer.Sign(bytes.NewReader(nil), msg, crypto.Hash(0))
+		if err == nil {
+			t.Errorf("Signing with exhausted randomness should fail for version %d", i)
+		}
+		_, err = signer.Sign(rand, msg, crypto.SHA256)
+		if err == nil {
+			t.Errorf("Signing digest of wrong length should fail for version %d", i)
+		}
+		digest := sha256.Sum256(msg)
+		rand = bytes.NewReader(bytes.Repeat([]byte{8}, 64))
+		enc, err = signer.Sign(rand, digest[:], crypto.SHA256)
+		if err != nil {
+			t.Errorf("Failed to generate pre-hash signature for version %d: %s", i, err.Error())
+			continue
+		}
+		sig, err = DeserializeBlissSignature(enc)
+		if err != nil {
+			t.Errorf("Error in decoding signature : %s", err.Error())
+			continue
+		}
+		err = verified(pub.VerifyDigest(crypto.SHA256, digest[:], sig))
+		if err != nil {
+			t.Errorf("Failed to verify pre-hash signature for version %d: %s", i, err.Error())
+		}
+	}
+}
+
+func TestPublicKeyEqual(t *testing.T) {
+	seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+	entropy, err := sampler.NewEntropy(seed)
+	if err != nil {
+		t.Errorf("Error in initializing entropy: %s", err.Error())
+	}
+	key1, err := GeneratePrivateKey(1, entropy)
+	if err != nil {
+		t.Errorf("Error in generating private key: %s", err.Error())
+	}
+	key2, err := GeneratePrivateKey(1, entropy)
+	if err != nil {
+		t.Errorf("Error in generating private key: %s", err.Error())
+	}
+	enc, err := key1.PublicKey().Serialize()
+	if err != nil {
+		t.Errorf("Error in encoding public key: %s", err.Error())
+	}
+	pub, err := DeserializeBlissPublicKey(enc)
+	if err != nil {
+		t.Errorf("Error in decoding public key: %s", err.Error())
+	}
+	if !key1.PublicKey().Equal(pub) {
+		t.Errorf("Equal public keys reported different")
+	}
+	if key1.PublicKey().Equal(key2.PublicKey()) {
+		t.Errorf("Different public keys reported equal")
+	}
+	if key1.PublicKey().Equal(key1) {
+		t.Errorf("Private key reported equal to public key")
+	}
+}
@@ -0,0 +1,84 @@
+package bliss
+
+import (
+	"fmt"
+	"golang.org/x/crypto/sha3"
+	"strings"
+)
+
+// The customization string of the cSHAKE256 instances deriving the secrets
+// of the nodes of a derivation path. Changing it changes every derived key.
+const deriveTag = "BLISS-B key derivation"
+
+// The bytes prefixed to the input of the XOF to tell the master node from
+// the child nodes.
+const (
+	deriveMaster byte = 0
+	deriveChild  byte = 1
+)
+
+// Split a derivation path like "m/service/signing/3" into its labels. The
+// path starts with "m" for the master node, and every label after it is
+// non-empty and at most 255 bytes.
+func parseDerivationPath(path string) ([]string, error) {
+	parts := strings.Split(path, "/")
+	if parts[0] != "m" {
+		return nil, fmt.Errorf("Invalid derivation path %q: expected to start with \"m\"", path)
+	}
+	labels := parts[1:]
+	for _, label := range labels {
+		if len(label) == 0 || len(label) > 255 {
+			return nil, fmt.Errorf("Invalid derivation path %q: label of length %d", path, len(label))
+		}
+	}
+	return labels, nil
+}
+
+// Derive the secret of a node by cSHAKE256 with the customization string
+// deriveTag. The input is the node type byte, followed by the parent secret
+// (or the master secret) and the label, both prefixed by their lengths.
+func deriveNode(kind byte, parent []byte, label string) []byte {
+	h := sha3.NewCShake256(nil, []byte(deriveTag))
+	h.Write([]byte{kind, byte(len(parent) >> 8), byte(len(parent))})
+	h.Write(parent)
+	h.Write([]byte{byte(len(label))})
+	h.Write([]byte(label))
+	node := make([]byte, LongSeedSize)
+	h.Read(node)
+	return node
+}
+
+// Derive the LongSeedSize-byte seed of the node at path, like
+// "m/service/signing/3", from a master secret of at least SeedSize bytes.
+// The seed of each node is derived from the seed of its parent and its
+// label, so the result is the same across releases and independent of the
+// BLISS version.
+func DeriveSeed(master []byte, path string) ([]byte, error) {
+	if len(master) < SeedSize || len(master) > 0xffff {
+		return nil, fmt.Errorf("Invalid master secret length, expected %d to %d, got %d",
+			SeedSize, 0xffff, len(master))
+	}
+	labels, err := parseDerivationPath(path)
+	if err != nil {
+		return nil, err
+	}
+	node := deriveNode(deriveMaster, master, "")
+	for _, label := range labels {
+		child := deriveNode(deriveChild, node, label)
+		wipeBytes(node)
+		node = child
+	}
+	return node, nil
+}
+
+// Derive the BLISS private key of the given version at path from a master
+// secret. It is GenerateKeyFromSeed with the seed of DeriveSeed, so Seed of
+// the key returns the seed of the node.
+func DeriveKey(master []byte, version int, path string) (*BlissPrivateKey, error) {
+	seed, err := DeriveSeed(master, path)
+	if err != nil {
+		return nil, err
+	}
+	defer wipeBytes(seed)
+	return GenerateKeyFromSeed(version, seed)
+}
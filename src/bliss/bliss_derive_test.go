@@ -0,0 +1,82 @@
+package bliss
+
+import (
+	"bytes"
+	"encoding/hex"
+	"golang.org/x/crypto/sha3"
+	"testing"
+)
+
+func TestDeriveKey(t *testing.T) {
+	master := make([]byte, SeedSize)
+	for i := range master {
+		master[i] = byte(i)
+	}
+
+	// The derivation must not change across releases.
+	seeds := map[string]string{
+		"m":                   "39fa1ff407222b9e0e814ced352ea4d3e92ec90d700340f060ccab5cfcf8d7365b90705e81e4835f2ba3bb39db98db8b828089c1fc30ba4b7a97a794f92ad91c",
+		"m/service/signing/3": "137001fa4ba01c75d708b07bded5f23ab1d9cb5499202a5328e6ae34486e76a56fe5b7495bc308ef00f6986673abe0a418341aecf1c769ef3f12d6739489148e",
+	}
+	for path, expect := range seeds {
+		seed, err := DeriveSeed(master, path)
+		if err != nil {
+			t.Errorf("Error in deriving seed: %s", err.Error())
+			continue
+		}
+		if hex.EncodeToString(seed) != expect {
+			t.Errorf("Wrong seed for %s: expect %s, got %x", path, expect, seed)
+		}
+	}
+	pubs := []string{
+		"3b9503438db396fe454a1077f61b1041b6ccd12e620430b0bb7fed14ca804017",
+		"dcbcda7709910c4d2654fcabffac92e73106c084eb96ddea30e7e98f6784e7b4",
+		"08c0a2cedb62cfc0f576488c0d5c3502aeabd19978ad632999fbc228d80d2c9d",
+		"3a4cf6a3f14ec3444204a49deab9cdfd3f95903d66f29d5bb8d08783bc3f3e30",
+		"0939ac6d9102747317d70b18334ed4e311f02af28c08bcc7077cce0ca2508ec1",
+	}
+	for i := 0; i <= 4; i++ {
+		key, err := DeriveKey(master, i, "m/service/signing/3")
+		if err != nil {
+			t.Errorf("Error in deriving private key: %s", err.Error())
+			continue
+		}
+		enc, err := key.PublicKey().Serialize()
+		if err != nil {
+			t.Errorf("Error in encoding public key: %s", err.Error())
+			continue
+		}
+		digest := sha3.Sum256(enc)
+		if hex.EncodeToString(digest[:]) != pubs[i] {
+			t.Errorf("Wrong public key derived for version %d: got digest %x", i, digest)
+		}
+		seed, _ := DeriveSeed(master, "m/service/signing/3")
+		if !bytes.Equal(key.Seed(), seed) {
+			t.Errorf("Wrong seed of derived key for version %d", i)
+		}
+	}
+
+	// Different paths give different seeds.
+	paths := []string{"m", "m/a", "m/b", "m/a/b", "m/ab", "m/b/a", "m/a/b/c"}
+	derived := map[string]string{}
+	for _, path := range paths {
+		seed, err := DeriveSeed(master, path)
+		if err != nil {
+			t.Errorf("Error in deriving seed: %s", err.Error())
+			continue
+		}
+		if other, ok := derived[string(seed)]; ok {
+			t.Errorf("Same seed for %s and %s", path, other)
+		}
+		derived[string(seed)] = path
+	}
+
+	for _, path := range []string{"", "/a", "n/a", "m/", "m//a", "m/a/", "a/m"} {
+		if _, err := DeriveSeed(master, path); err == nil {
+			t.Errorf("Invalid path %q should be rejected", path)
+		}
+	}
+	if _, err := DeriveSeed(master[:SeedSize-1], "m"); err == nil {
+		t.Errorf("Short master secret should be rejected")
+	}
+}
@@ -0,0 +1,54 @@
+package bliss
+
+import (
+	"errors"
+)
+
+// The error returned when a destroyed private key is used for signing or
+// serialization.
+var ErrKeyDestroyed = errors.New("Private key destroyed")
+
+// Overwrite a byte slice holding secret data with zeros.
+func wipeBytes(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
+
+// Wipe the secret polynomials s1,s2 and the seed of the private key, and
+// release the locked memory of LockMemory. The public key a is kept, so
+// PublicKey and Param still work, but signing and serialization fail with
+// ErrKeyDestroyed. Destroying a key twice is harmless.
+// Destroy is not synchronized with the other methods. It must not run
+// concurrently with any use of the key, including a Signer or CryptoSigner
+// built on it, since the locked pages are unmapped and a concurrent signing
+// would fault on them.
+func (privateKey *BlissPrivateKey) Destroy() {
+	privateKey.s1.Wipe()
+	privateKey.s2.Wipe()
+	wipeBytes(privateKey.seed)
+	privateKey.unlockMemory()
+	privateKey.s1 = nil
+	privateKey.s2 = nil
+	privateKey.seed = nil
+}
+
+// Check whether the private key has been destroyed.
+func (privateKey *BlissPrivateKey) destroyed() bool {
+	return privateKey.s1 == nil || privateKey.s2 == nil
+}
+
+// Wipe the scratch polynomials of signing.
+func (scratch *signScratch) wipe() {
+	scratch.y1.Wipe()
+	scratch.y2.Wipe()
+	scratch.y1beta.Wipe()
+	scratch.y2beta.Wipe()
+}
+
+// Wipe the secret polynomials of a commitment, once its attempt is over.
+func (c *commitment) wipe() {
+	c.y1.Wipe()
+	c.y2.Wipe()
+	c.v.Wipe()
+}
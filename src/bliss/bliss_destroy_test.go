@@ -0,0 +1,164 @@
+package bliss
+
+import (
+	"errors"
+	"poly"
+	"reflect"
+	"sampler"
+	"testing"
+)
+
+func TestDestroy(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		key, err := GenerateKeyFromSeed(i, seed)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+			continue
+		}
+		pub := key.PublicKey()
+		s1, s2, keySeed := key.s1.GetData(), key.s2.GetData(), key.seed
+
+		key.Destroy()
+		for j := range s1 {
+			if s1[j] != 0 || s2[j] != 0 {
+				t.Errorf("Private key not wiped at %d for version %d", j, i)
+				break
+			}
+		}
+		for j := range keySeed {
+			if keySeed[j] != 0 {
+				t.Errorf("Seed not wiped at %d for version %d", j, i)
+				break
+			}
+		}
+		if key.Seed() != nil || key.Param().Version != i || !key.PublicKey().Equal(pub) ||
+			key.String() != "{destroyed,a:"+key.a.String()+"}" {
+			t.Errorf("Wrong state of destroyed key for version %d", i)
+		}
+		entropy, _ := sampler.NewEntropy(seed)
+		if _, err := key.Sign([]byte("Hello world"), entropy); !errors.Is(err, ErrKeyDestroyed) {
+			t.Errorf("Expect ErrKeyDestroyed from Sign, got %v", err)
+		}
+		if _, err := key.SignDeterministic([]byte("Hello world")); !errors.Is(err, ErrKeyDestroyed) {
+			t.Errorf("Expect ErrKeyDestroyed from SignDeterministic, got %v", err)
+		}
+		if _, err := key.Serialize(); !errors.Is(err, ErrKeyDestroyed) {
+			t.Errorf("Expect ErrKeyDestroyed from Serialize, got %v", err)
+		}
+		if err := key.Validate(); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Expect ErrInvalidKey from Validate, got %v", err)
+		}
+		key.Destroy()
+	}
+}
+
+func TestSignWipesTemporaries(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+			continue
+		}
+		s, err := sampler.New(i, entropy)
+		if err != nil {
+			t.Errorf("Error in initializing sampler: %s", err.Error())
+			continue
+		}
+		scratch, _ := newSignScratch(key.Param())
+		zero, _ := poly.NewPolyArray(key.Param())
+		hash := make([]byte, sampler.SHA_512_DIGEST_LENGTH)
+		for _, ct := range []bool{false, true} {
+			for sig := (*BlissSignature)(nil); sig == nil; {
+				var c *commitment
+				if ct {
+					c, err = key.commitAgainstSideChannel(s, entropy, scratch)
+				} else {
+					c, err = key.commit(s, entropy, scratch.y1, scratch.y2)
+				}
+				if err != nil {
+					t.Errorf("Error in commitment: %s", err.Error())
+					break
+				}
+				sig, err = key.respond(c, hash, nil)
+				if err != nil {
+					t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+					break
+				}
+				for _, p := range []*poly.PolyArray{c.y1, c.y2, c.v, scratch.y1, scratch.y2, scratch.y1beta, scratch.y2beta} {
+					if !reflect.DeepEqual(p.GetData(), zero.GetData()) {
+						t.Errorf("Temporary polynomial not wiped for version %d", i)
+						break
+					}
+				}
+			}
+		}
+		s.Destroy()
+	}
+}
+
+func TestSignerDestroy(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		key, err := GenerateKeyFromSeed(i, seed)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+			continue
+		}
+		signer, err := NewSigner(key)
+		if err != nil {
+			t.Errorf("Error in creating signer: %s", err.Error())
+			continue
+		}
+		msg := []byte("Hello world")
+		if _, err := signer.SignDeterministic(msg); err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+		}
+
+		signer.Destroy()
+		if signer.nonceKey != [64]byte{} {
+			t.Errorf("Nonce key of signer not wiped for version %d", i)
+		}
+		entropy, _ := sampler.NewEntropy(seed)
+		if _, err := signer.Sign(msg, entropy); !errors.Is(err, ErrKeyDestroyed) {
+			t.Errorf("Expect ErrKeyDestroyed from Sign, got %v", err)
+		}
+		if _, err := signer.SignAgainstSideChannel(msg, entropy); !errors.Is(err, ErrKeyDestroyed) {
+			t.Errorf("Expect ErrKeyDestroyed from SignAgainstSideChannel, got %v", err)
+		}
+		if _, err := signer.SignDeterministic(msg); !errors.Is(err, ErrKeyDestroyed) {
+			t.Errorf("Expect ErrKeyDestroyed from SignDeterministic, got %v", err)
+		}
+		if _, err := signer.SignHedged(msg, nil); !errors.Is(err, ErrKeyDestroyed) {
+			t.Errorf("Expect ErrKeyDestroyed from SignHedged, got %v", err)
+		}
+		signer.Destroy()
+		// The private key itself is not destroyed with the signer.
+		if _, err := key.SignDeterministic(msg); err != nil {
+			t.Errorf("Failed to sign with key of destroyed signer for version %d: %s", i, err.Error())
+		}
+
+		cryptoSigner := NewCryptoSigner(key)
+		cryptoSigner.Destroy()
+		if _, err := cryptoSigner.Sign(nil, msg, nil); !errors.Is(err, ErrKeyDestroyed) {
+			t.Errorf("Expect ErrKeyDestroyed from CryptoSigner.Sign, got %v", err)
+		}
+		if !key.PublicKey().Equal(cryptoSigner.Public()) {
+			t.Errorf("Wrong public key of destroyed CryptoSigner for version %d", i)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package bliss
+
+import (
+	cryptorand "crypto/rand"
+	"fmt"
+	"golang.org/x/crypto/sha3"
+	"io"
+	"sampler"
+)
+
+// The domain separation tags of the hashes used to derive the entropy in the
+// deterministic and hedged signing modes.
+const (
+	nonceKeyTag  = "BLISS-B nonce key"
+	nonceSeedTag = "BLISS-B nonce seed"
+)
+
+// Derive the secret of the private key that is used only for deriving the
+// entropy of signing. It is the hash of the serialized private key, so it
+// is determined by (s1,s2) and needs no extra storage. The caller must wipe
+// the secret after use.
+func (key *BlissPrivateKey) nonceKey() ([64]byte, error) {
+	enc, err := key.Serialize()
+	if err != nil {
+		return [64]byte{}, err
+	}
+	data := append([]byte(nonceKeyTag), enc...)
+	defer wipeBytes(data)
+	defer wipeBytes(enc)
+	return sha3.Sum512(data), nil
+}
+
+// Derive the entropy of signing from the key secret, the message hash fed
+// into computeC and the optional fresh randomness rnd, in the spirit of
+// RFC 6979. The seed is SHA3-512(tag||secret||len(rnd)||rnd||hash), where
+// len(rnd) takes one byte, so each part is unambiguous.
+func nonceEntropy(secret *[64]byte, hash, rnd []byte) (*sampler.Entropy, error) {
+	if len(rnd) > 255 {
+		return nil, fmt.Errorf("Randomness too long, expected <= 255, got %d", len(rnd))
+	}
+	data := append([]byte(nonceSeedTag), secret[:]...)
+	data = append(data, byte(len(rnd)))
+	data = append(data, rnd...)
+	data = append(data, hash...)
+	defer wipeBytes(data)
+	seed := sha3.Sum512(data)
+	defer wipeBytes(seed[:])
+	return sampler.NewEntropy(seed[:])
+}
+
+// Read the 64 bytes of fresh randomness of the hedged signing mode from rand.
+// If rand is nil, crypto/rand.Reader is used.
+func readHedge(rand io.Reader) ([]byte, error) {
+	if rand == nil {
+		rand = cryptorand.Reader
+	}
+	rnd := make([]byte, sampler.SHA_512_DIGEST_LENGTH)
+	if _, err := io.ReadFull(rand, rnd); err != nil {
+		return nil, fmt.Errorf("Failed to read random seed: %s", err.Error())
+	}
+	return rnd, nil
+}
+
+// The deterministic BLISS signature generation algorithm. The entropy is
+// derived from a secret of the key and the message, so signing the same
+// message twice gives the same signature, and different messages never share
+// the sampled y1,y2.
+func (key *BlissPrivateKey) SignDeterministic(msg []byte) (*BlissSignature, error) {
+	hash := sha3.Sum512(msg)
+	secret, err := key.nonceKey()
+	if err != nil {
+		return nil, err
+	}
+	entropy, err := nonceEntropy(&secret, hash[:], nil)
+	wipeBytes(secret[:])
+	if err != nil {
+		return nil, err
+	}
+	defer entropy.Destroy()
+	return key.sign(hash[:], entropy)
+}
+
+// The hedged BLISS signature generation algorithm. The entropy is derived as
+// in SignDeterministic, with 64 bytes of fresh randomness read from rand mixed
+// in. The signatures are randomized, and remain safe even if rand is broken.
+// If rand is nil, crypto/rand.Reader is used.
+func (key *BlissPrivateKey) SignHedged(msg []byte, rand io.Reader) (*BlissSignature, error) {
+	hash := sha3.Sum512(msg)
+	return key.signHedged(hash[:], rand)
+}
+
+// The hedged BLISS signature generation algorithm, given the message hash
+// that is fed into computeC.
+func (key *BlissPrivateKey) signHedged(hash []byte, rand io.Reader) (*BlissSignature, error) {
+	rnd, err := readHedge(rand)
+	if err != nil {
+		return nil, err
+	}
+	defer wipeBytes(rnd)
+	secret, err := key.nonceKey()
+	if err != nil {
+		return nil, err
+	}
+	entropy, err := nonceEntropy(&secret, hash, rnd)
+	wipeBytes(secret[:])
+	if err != nil {
+		return nil, err
+	}
+	defer entropy.Destroy()
+	return key.sign(hash, entropy)
+}
@@ -0,0 +1,103 @@
+package bliss
+
+import (
+	"bytes"
+	"reflect"
+	"sampler"
+	"testing"
+)
+
+func TestSignDeterministic(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+		}
+
+		pub := key.PublicKey()
+		msg := []byte("Hello world")
+		sig1, err := key.SignDeterministic(msg)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		sig2, err := key.SignDeterministic(msg)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(sig1, sig2) {
+			t.Errorf("Different deterministic signatures for version %d", i)
+		}
+		err = verified(pub.Verify(msg, sig1))
+		if err != nil {
+			t.Errorf("Failed to verify signature for version %d: %s", i, err.Error())
+		}
+		sig3, err := key.SignDeterministic([]byte("Hello world!"))
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		if reflect.DeepEqual(sig1.z1, sig3.z1) {
+			t.Errorf("Same z1 for different messages for version %d", i)
+		}
+	}
+}
+
+func TestSignHedged(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+		}
+
+		pub := key.PublicKey()
+		msg := []byte("Hello world")
+		rnd := bytes.Repeat([]byte{1}, 64)
+		sig1, err := key.SignHedged(msg, bytes.NewReader(rnd))
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		err = verified(pub.Verify(msg, sig1))
+		if err != nil {
+			t.Errorf("Failed to verify signature for version %d: %s", i, err.Error())
+		}
+		// Reusing the same randomness for another message must not reuse y1.
+		sig2, err := key.SignHedged([]byte("Hello world!"), bytes.NewReader(rnd))
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		if reflect.DeepEqual(sig1.z1, sig2.z1) {
+			t.Errorf("Same z1 for different messages for version %d", i)
+		}
+		sig3, err := key.SignDeterministic(msg)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		if reflect.DeepEqual(sig1, sig3) {
+			t.Errorf("Hedged signature equals deterministic one for version %d", i)
+		}
+		_, err = key.SignHedged(msg, bytes.NewReader(nil))
+		if err == nil {
+			t.Errorf("Signing with exhausted randomness should fail for version %d", i)
+		}
+	}
+}
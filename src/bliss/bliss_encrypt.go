@@ -0,0 +1,162 @@
+package bliss
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	cryptorand "crypto/rand"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/scrypt"
+	"io"
+)
+
+// The layout of an encrypted private key:
+//
+//	magic       4 bytes  "BLKE"
+//	format      1 byte   encryptedKeyFormat
+//	version     1 byte   the BLISS version
+//	fingerprint 32 bytes Fingerprint of the public key
+//	logN        1 byte   scrypt cost N = 2^logN
+//	r           1 byte   scrypt block size
+//	p           1 byte   scrypt parallelization
+//	salt        16 bytes scrypt salt
+//	nonce       12 bytes AES-GCM nonce
+//	ciphertext           AES-256-GCM of Serialize(), with the header above
+//	                     as additional data
+const (
+	encryptedKeyMagic  = "BLKE"
+	encryptedKeyFormat = 1
+	saltSize           = 16
+	nonceSize          = 12
+	headerSize         = len(encryptedKeyMagic) + 2 + FingerprintSize + 3 + saltSize + nonceSize
+)
+
+// The scrypt parameters of EncryptPrivateKey, and the limits of
+// DecryptPrivateKey on a crafted file. scrypt holds 128*r*N bytes and runs
+// in time proportional to 128*r*N*p, so DecryptPrivateKey bounds 128*r*N*p
+// by scryptMaxCost, which is 256 MiB of memory at p = 1 and 8 times the
+// cost of EncryptPrivateKey. scryptMaxLogN keeps the product from
+// overflowing.
+const (
+	scryptLogN    = 15
+	scryptR       = 8
+	scryptP       = 1
+	scryptMaxLogN = 20
+	scryptMaxCost = 256 << 20
+)
+
+// The error returned by DecryptPrivateKey if the authentication fails. The
+// passphrase is wrong, or the data is corrupted, and the two cannot be told
+// apart.
+var ErrWrongPassphrase = errors.New("Wrong passphrase or corrupted private key")
+
+// Derive the AES-256 key from the passphrase by scrypt.
+func passphraseKey(passphrase, salt []byte, logN, r, p byte) ([]byte, error) {
+	return scrypt.Key(passphrase, salt, 1<<logN, int(r), int(p), 32)
+}
+
+// Encrypt the BLISS private key with a passphrase. The key from
+// Serialize is encrypted by AES-256-GCM under a key derived by scrypt with a
+// random salt. The header, which carries the BLISS version, the fingerprint
+// of the public key and the scrypt parameters, is authenticated but not
+// encrypted.
+func EncryptPrivateKey(key *BlissPrivateKey, passphrase []byte) ([]byte, error) {
+	return encryptPrivateKey(key, passphrase, cryptorand.Reader, scryptLogN)
+}
+
+// The EncryptPrivateKey procedure with the given randomness and scrypt cost.
+func encryptPrivateKey(key *BlissPrivateKey, passphrase []byte, rand io.Reader, logN byte) ([]byte, error) {
+	plaintext, err := key.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	defer wipeBytes(plaintext)
+	fingerprint, err := key.PublicKey().Fingerprint()
+	if err != nil {
+		return nil, err
+	}
+	header := append([]byte(encryptedKeyMagic), encryptedKeyFormat, byte(key.Param().Version))
+	header = append(header, fingerprint...)
+	header = append(header, logN, scryptR, scryptP)
+	random := make([]byte, saltSize+nonceSize)
+	if _, err := io.ReadFull(rand, random); err != nil {
+		return nil, fmt.Errorf("Failed to read random salt: %s", err.Error())
+	}
+	header = append(header, random...)
+	salt, nonce := random[:saltSize], random[saltSize:]
+
+	aead, err := passphraseAEAD(passphrase, salt, logN, scryptR, scryptP)
+	if err != nil {
+		return nil, err
+	}
+	return aead.Seal(header, nonce, plaintext, header), nil
+}
+
+// Build the AES-256-GCM instance keyed by the passphrase.
+func passphraseAEAD(passphrase, salt []byte, logN, r, p byte) (cipher.AEAD, error) {
+	k, err := passphraseKey(passphrase, salt, logN, r, p)
+	if err != nil {
+		return nil, err
+	}
+	defer wipeBytes(k)
+	block, err := aes.NewCipher(k)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Decrypt the BLISS private key encrypted by EncryptPrivateKey. An error
+// wrapping ErrWrongPassphrase is returned if the passphrase is wrong, and an
+// error wrapping ErrMalformedEncoding if the data is not an encrypted key,
+// or the decrypted key does not match the version and fingerprint in the
+// header.
+func DecryptPrivateKey(data, passphrase []byte) (*BlissPrivateKey, error) {
+	if len(data) < headerSize || string(data[:len(encryptedKeyMagic)]) != encryptedKeyMagic {
+		return nil, fmt.Errorf("Not an encrypted private key: %w", ErrMalformedEncoding)
+	}
+	header := data[:headerSize]
+	rest := header[len(encryptedKeyMagic):]
+	format, version, rest := rest[0], rest[1], rest[2:]
+	if format != encryptedKeyFormat {
+		return nil, fmt.Errorf("Unknown encrypted key format %d: %w", format, ErrMalformedEncoding)
+	}
+	fingerprint, rest := rest[:FingerprintSize], rest[FingerprintSize:]
+	logN, r, p, rest := rest[0], rest[1], rest[2], rest[3:]
+	if logN == 0 || logN > scryptMaxLogN || r == 0 || p == 0 ||
+		128*int64(r)*int64(p)<<logN > scryptMaxCost {
+		return nil, fmt.Errorf("Unsupported scrypt parameters logN=%d r=%d p=%d: %w",
+			logN, r, p, ErrMalformedEncoding)
+	}
+	salt, nonce := rest[:saltSize], rest[saltSize:]
+
+	aead, err := passphraseAEAD(passphrase, salt, logN, r, p)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, nonce, data[headerSize:], header)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to decrypt private key: %w", ErrWrongPassphrase)
+	}
+	key, err := DeserializeBlissPrivateKey(plaintext)
+	wipeBytes(plaintext)
+	if err != nil {
+		return nil, err
+	}
+	if key.Param().Version != int(version) {
+		key.Destroy()
+		return nil, fmt.Errorf("Decrypted key of version %d, header says %d: %w",
+			key.Param().Version, version, ErrMalformedEncoding)
+	}
+	digest, err := key.PublicKey().Fingerprint()
+	if err != nil {
+		key.Destroy()
+		return nil, err
+	}
+	if !bytes.Equal(digest, fingerprint) {
+		key.Destroy()
+		return nil, fmt.Errorf("Decrypted key does not match fingerprint: %w", ErrMalformedEncoding)
+	}
+	return key, nil
+}
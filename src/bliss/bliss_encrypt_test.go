@@ -0,0 +1,98 @@
+package bliss
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"sampler"
+	"testing"
+)
+
+func TestEncryptPrivateKey(t *testing.T) {
+	passphrase := []byte("correct horse battery staple")
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+			continue
+		}
+		// A low scrypt cost keeps the test fast.
+		rand := bytes.NewReader(bytes.Repeat([]byte{byte(i)}, 64))
+		enc, err := encryptPrivateKey(key, passphrase, rand, 10)
+		if err != nil {
+			t.Errorf("Error in encrypting private key: %s", err.Error())
+			continue
+		}
+		plain, _ := key.Serialize()
+		if bytes.Contains(enc, plain[1:]) {
+			t.Errorf("Encrypted key contains the plain key for version %d", i)
+		}
+		tmp, err := DecryptPrivateKey(enc, passphrase)
+		if err != nil {
+			t.Errorf("Error in decrypting private key: %s", err.Error())
+		} else if !reflect.DeepEqual(key, tmp) {
+			t.Errorf("Different private key decrypted for version %d", i)
+		}
+
+		_, err = DecryptPrivateKey(enc, []byte("wrong passphrase"))
+		if !errors.Is(err, ErrWrongPassphrase) {
+			t.Errorf("Expect ErrWrongPassphrase for version %d, got %v", i, err)
+		}
+		// Every byte of the header and the ciphertext is authenticated.
+		for _, j := range []int{5, 6, 38, headerSize - 1, headerSize, len(enc) - 1} {
+			bad := append([]byte{}, enc...)
+			bad[j] ^= 1
+			if _, err := DecryptPrivateKey(bad, passphrase); err == nil {
+				t.Errorf("Modified byte %d accepted for version %d", j, i)
+			}
+		}
+		if _, err := DecryptPrivateKey(enc[:headerSize-1], passphrase); !errors.Is(err, ErrMalformedEncoding) {
+			t.Errorf("Expect ErrMalformedEncoding for truncated data, got %v", err)
+		}
+	}
+
+	key, err := GenerateKeyFromSeed(1, make([]byte, SeedSize))
+	if err != nil {
+		t.Errorf("Error in generating private key: %s", err.Error())
+		return
+	}
+	enc, err := EncryptPrivateKey(key, passphrase)
+	if err != nil {
+		t.Errorf("Error in encrypting private key: %s", err.Error())
+		return
+	}
+	tmp, err := DecryptPrivateKey(enc, passphrase)
+	if err != nil {
+		t.Errorf("Error in decrypting private key: %s", err.Error())
+	} else if !key.PublicKey().Equal(tmp.PublicKey()) {
+		t.Errorf("Different private key decrypted")
+	}
+	enc2, _ := EncryptPrivateKey(key, passphrase)
+	if bytes.Equal(enc, enc2) {
+		t.Errorf("Encrypting twice gives the same data")
+	}
+
+	// A crafted scrypt cost is refused before deriving the key.
+	slot := len(encryptedKeyMagic) + 2 + FingerprintSize
+	for _, cost := range [][3]byte{
+		{40, scryptR, scryptP},
+		{scryptMaxLogN, scryptR, scryptP},
+		{18, scryptR, 2},
+		{16, 255, 255},
+	} {
+		tmp := append([]byte(nil), enc...)
+		copy(tmp[slot:], cost[:])
+		if _, err := DecryptPrivateKey(tmp, passphrase); !errors.Is(err, ErrMalformedEncoding) {
+			t.Errorf("Expect ErrMalformedEncoding for scrypt cost %v, got %v", cost, err)
+		}
+	}
+}
@@ -0,0 +1,24 @@
+package bliss
+
+import (
+	"errors"
+	"poly"
+)
+
+// The sentinel errors of this package. The errors returned by the functions
+// wrap them with the details, so they should be tested by errors.Is.
+var (
+	// The signature is rejected by the verification, or cannot be a valid
+	// signature at all.
+	ErrInvalidSignature = errors.New("Invalid signature")
+	// The signature and the key, or two keys, are of different BLISS
+	// versions.
+	ErrVersionMismatch = errors.New("Mismatched BLISS version")
+	// The data is not in the binary format of Serialize, or the object
+	// cannot be put in that format.
+	ErrMalformedEncoding = errors.New("Malformed encoding")
+	// The key does not have the shape of a BLISS key, see Validate.
+	ErrInvalidKey = errors.New("Invalid key")
+	// The polynomial f of a private key is not invertible mod q.
+	ErrNotInvertible = poly.ErrNotInvertible
+)
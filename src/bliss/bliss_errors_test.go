@@ -0,0 +1,116 @@
+package bliss
+
+import (
+	"errors"
+	"sampler"
+	"testing"
+)
+
+// Merge the results of a verification into an error, which is nil only if
+// the signature is valid.
+func verified(ok bool, err error) error {
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func TestSentinelErrors(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+		}
+		other, err := GeneratePrivateKey((i+1)%5, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+		}
+		pub := key.PublicKey()
+
+		msg := []byte("Hello world")
+		sig, err := key.Sign(msg, entropy)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+
+		// A rejected signature is false without error.
+		ok, err := pub.Verify([]byte("Hello world!"), sig)
+		if ok || err != nil {
+			t.Errorf("Expect rejection without error, got %v, %v", ok, err)
+		}
+		// Malformed input is false with error.
+		ok, err = other.PublicKey().Verify(msg, sig)
+		if ok || !errors.Is(err, ErrVersionMismatch) {
+			t.Errorf("Expect ErrVersionMismatch, got %v, %v", ok, err)
+		}
+		ok, err = pub.Verify(msg, nil)
+		if ok || !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("Expect ErrInvalidSignature, got %v, %v", ok, err)
+		}
+
+		enc, err := sig.Serialize()
+		if err != nil {
+			t.Errorf("Failed to encode signature for version %d: %s", i, err.Error())
+			continue
+		}
+		for _, data := range [][]byte{{}, {0xff}, enc[:len(enc)/2]} {
+			_, err = DeserializeBlissSignature(data)
+			if !errors.Is(err, ErrMalformedEncoding) {
+				t.Errorf("Expect ErrMalformedEncoding for signature, got %v", err)
+			}
+		}
+		penc, err := pub.Serialize()
+		if err != nil {
+			t.Errorf("Error in encoding public key: %s", err.Error())
+			continue
+		}
+		_, err = DeserializeBlissPublicKey(penc[1:])
+		if !errors.Is(err, ErrMalformedEncoding) {
+			t.Errorf("Expect ErrMalformedEncoding for public key, got %v", err)
+		}
+
+		// A private key with f = 0 cannot be inverted.
+		kenc, err := key.Serialize()
+		if err != nil {
+			t.Errorf("Error in encoding private key: %s", err.Error())
+			continue
+		}
+		// Eight zero coefficients, each stored as 010, fill 3 bytes.
+		zeros := []byte{0x49, 0x24, 0x92}
+		n := int(key.Param().N)
+		for j := 0; j < 3*n/8; j++ {
+			kenc[1+j] = zeros[j%3]
+		}
+		_, err = DeserializeBlissPrivateKey(kenc)
+		if !errors.Is(err, ErrNotInvertible) {
+			t.Errorf("Expect ErrNotInvertible, got %v", err)
+		}
+	}
+}
+
+func TestVerifyFailureErr(t *testing.T) {
+	if VerifyOK.Err() != nil {
+		t.Errorf("Expect nil error for VerifyOK")
+	}
+	if !errors.Is(VerifyVersionMismatch.Err(), ErrVersionMismatch) {
+		t.Errorf("Expect ErrVersionMismatch for VerifyVersionMismatch")
+	}
+	for _, failure := range []VerifyFailure{VerifyZ1MaxNorm, VerifyZ2MaxNorm, VerifyL2Norm, VerifyIndicesMismatch} {
+		if !errors.Is(failure.Err(), ErrInvalidSignature) {
+			t.Errorf("Expect ErrInvalidSignature for %s", failure.String())
+		}
+	}
+}
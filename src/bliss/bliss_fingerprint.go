@@ -0,0 +1,72 @@
+package bliss
+
+import (
+	"encoding/hex"
+	"fmt"
+	"golang.org/x/crypto/sha3"
+	"strings"
+)
+
+// The sizes of a fingerprint and of a key ID in bytes.
+const (
+	FingerprintSize = 32
+	KeyIDSize       = 8
+)
+
+// The prefix of a fingerprint in the human readable form.
+const fingerprintPrefix = "SHA3-256:"
+
+// Compute the fingerprint of the BLISS public key, i.e. the SHA3-256 digest
+// of its binary form from Serialize: the version byte followed by the
+// encoding of the coefficients. Equal keys always have the same fingerprint,
+// and keys of different versions never share one.
+func (publicKey *BlissPublicKey) Fingerprint() ([]byte, error) {
+	enc, err := publicKey.Serialize()
+	if err != nil {
+		return nil, err
+	}
+	digest := sha3.Sum256(enc)
+	return digest[:], nil
+}
+
+// Compute the key ID of the BLISS public key, the first KeyIDSize bytes of
+// its fingerprint. It is short enough to reference a key, e.g. in a key
+// store or a signature envelope, but comparisons out-of-band should use the
+// full fingerprint.
+func (publicKey *BlissPublicKey) KeyID() ([]byte, error) {
+	fingerprint, err := publicKey.Fingerprint()
+	if err != nil {
+		return nil, err
+	}
+	return fingerprint[:KeyIDSize], nil
+}
+
+// Format a fingerprint for display, as "SHA3-256:" followed by groups of 4
+// lower-case hex digits separated by colons.
+func FormatFingerprint(fingerprint []byte) string {
+	digits := hex.EncodeToString(fingerprint)
+	groups := make([]string, 0, (len(digits)+3)/4)
+	for i := 0; i < len(digits); i += 4 {
+		end := i + 4
+		if end > len(digits) {
+			end = len(digits)
+		}
+		groups = append(groups, digits[i:end])
+	}
+	return fingerprintPrefix + strings.Join(groups, ":")
+}
+
+// Parse a fingerprint in the form of FormatFingerprint. The prefix and the
+// colons may be omitted, and the hex digits are case-insensitive, so a
+// fingerprint read out by a person can be compared with bytes.Equal.
+func ParseFingerprint(s string) ([]byte, error) {
+	s = strings.TrimSpace(s)
+	if len(s) >= len(fingerprintPrefix) && strings.EqualFold(s[:len(fingerprintPrefix)], fingerprintPrefix) {
+		s = s[len(fingerprintPrefix):]
+	}
+	fingerprint, err := hex.DecodeString(strings.ReplaceAll(s, ":", ""))
+	if err != nil || len(fingerprint) != FingerprintSize {
+		return nil, fmt.Errorf("Invalid fingerprint %q", s)
+	}
+	return fingerprint, nil
+}
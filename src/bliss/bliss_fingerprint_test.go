@@ -0,0 +1,81 @@
+package bliss
+
+import (
+	"bytes"
+	"encoding/hex"
+	"golang.org/x/crypto/sha3"
+	"strings"
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	fingerprints := map[string]bool{}
+	for i := 0; i <= 4; i++ {
+		key, err := GenerateKeyFromSeed(i, make([]byte, SeedSize))
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+			continue
+		}
+		pub := key.PublicKey()
+		fingerprint, err := pub.Fingerprint()
+		if err != nil {
+			t.Errorf("Error in computing fingerprint: %s", err.Error())
+			continue
+		}
+		enc, _ := pub.Serialize()
+		digest := sha3.Sum256(enc)
+		if !bytes.Equal(fingerprint, digest[:]) || len(fingerprint) != FingerprintSize {
+			t.Errorf("Wrong fingerprint for version %d", i)
+		}
+		if fingerprints[string(fingerprint)] {
+			t.Errorf("Repeated fingerprint for version %d", i)
+		}
+		fingerprints[string(fingerprint)] = true
+
+		id, err := pub.KeyID()
+		if err != nil {
+			t.Errorf("Error in computing key ID: %s", err.Error())
+		} else if !bytes.Equal(id, fingerprint[:KeyIDSize]) {
+			t.Errorf("Wrong key ID for version %d", i)
+		}
+
+		tmp, _ := DeserializeBlissPublicKey(enc)
+		other, _ := tmp.Fingerprint()
+		if !bytes.Equal(fingerprint, other) {
+			t.Errorf("Different fingerprint of decoded public key for version %d", i)
+		}
+
+		s := FormatFingerprint(fingerprint)
+		for _, form := range []string{s, strings.ToUpper(s), " " + s[len("SHA3-256:"):] + "\n",
+			hex.EncodeToString(fingerprint)} {
+			parsed, err := ParseFingerprint(form)
+			if err != nil {
+				t.Errorf("Error in parsing fingerprint %q: %s", form, err.Error())
+			} else if !bytes.Equal(parsed, fingerprint) {
+				t.Errorf("Wrong fingerprint parsed from %q", form)
+			}
+		}
+	}
+
+	// The fingerprint must not change across releases.
+	master := make([]byte, SeedSize)
+	for i := range master {
+		master[i] = byte(i)
+	}
+	key, err := DeriveKey(master, 1, "m/service/signing/3")
+	if err != nil {
+		t.Errorf("Error in deriving private key: %s", err.Error())
+		return
+	}
+	fingerprint, _ := key.PublicKey().Fingerprint()
+	expect := "SHA3-256:dcbc:da77:0991:0c4d:2654:fcab:ffac:92e7:3106:c084:eb96:ddea:30e7:e98f:6784:e7b4"
+	if FormatFingerprint(fingerprint) != expect {
+		t.Errorf("Wrong fingerprint: expect %s, got %s", expect, FormatFingerprint(fingerprint))
+	}
+
+	for _, s := range []string{"", "SHA3-256:", "SHA3-256:dcbc", expect + ":00", "SHA3-256:zzzz" + expect[13:]} {
+		if _, err := ParseFingerprint(s); err == nil {
+			t.Errorf("Invalid fingerprint %q should be rejected", s)
+		}
+	}
+}
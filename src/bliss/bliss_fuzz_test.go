@@ -0,0 +1,212 @@
+package bliss
+
+import (
+	"bytes"
+	"reflect"
+	"sampler"
+	"testing"
+)
+
+// Generate a key for every version from a fixed seed, for the seed corpus
+// of the fuzz targets.
+func fuzzKeys(f *testing.F) []*BlissPrivateKey {
+	keys := make([]*BlissPrivateKey, 5)
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for j := 0; j < len(seed); j++ {
+			seed[j] = uint8(j % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			f.Fatalf("Error in initializing entropy: %s", err.Error())
+		}
+		keys[i], err = GeneratePrivateKey(i, entropy)
+		if err != nil {
+			f.Fatalf("Error in generating private key: %s", err.Error())
+		}
+	}
+	return keys
+}
+
+// Create the entropy from an arbitrary fuzzing input.
+func fuzzEntropy(t testing.TB, seed []byte) *sampler.Entropy {
+	buf := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+	copy(buf, seed)
+	entropy, err := sampler.NewEntropy(buf)
+	if err != nil {
+		t.Fatalf("Error in initializing entropy: %s", err.Error())
+	}
+	return entropy
+}
+
+func FuzzDeserializeBlissSignature(f *testing.F) {
+	keys := fuzzKeys(f)
+	for i, key := range keys {
+		entropy := fuzzEntropy(f, []byte{byte(i)})
+		sig, err := key.Sign([]byte("Hello world"), entropy)
+		if err != nil {
+			f.Fatalf("Failed to generate signature for version %d: %s", i, err.Error())
+		}
+		enc, err := sig.Serialize()
+		if err != nil {
+			f.Fatalf("Failed to encode signature for version %d: %s", i, err.Error())
+		}
+		f.Add(enc)
+		f.Add(enc[:len(enc)/2])
+	}
+	f.Add([]byte{})
+	f.Add([]byte{0xff})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		sig, err := DeserializeBlissSignature(data)
+		if err != nil {
+			return
+		}
+		version := sig.Param().Version
+		// Decoded signatures must be safe to verify.
+		keys[version].PublicKey().Verify([]byte("Hello world"), sig)
+		enc, err := sig.Serialize()
+		if err != nil {
+			return
+		}
+		tmp, err := DeserializeBlissSignature(enc)
+		if err != nil {
+			t.Fatalf("Failed to decode re-encoded signature: %s", err.Error())
+		}
+		if !reflect.DeepEqual(sig, tmp) {
+			t.Fatalf("Different signature after re-encoding")
+		}
+	})
+}
+
+func FuzzDeserializeBlissSignatureStrict(f *testing.F) {
+	keys := fuzzKeys(f)
+	for i, key := range keys {
+		sig, err := key.Sign([]byte("Hello world"), fuzzEntropy(f, []byte{byte(i)}))
+		if err != nil {
+			f.Fatalf("Failed to generate signature for version %d: %s", i, err.Error())
+		}
+		enc, err := sig.Serialize()
+		if err != nil {
+			f.Fatalf("Failed to encode signature for version %d: %s", i, err.Error())
+		}
+		f.Add(enc)
+		f.Add(append(enc, 0))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		sig, err := DeserializeBlissSignatureStrict(data)
+		if err != nil {
+			return
+		}
+		enc, err := sig.Serialize()
+		if err != nil {
+			t.Fatalf("Failed to encode accepted signature: %s", err.Error())
+		}
+		if !bytes.Equal(enc, data) {
+			t.Fatalf("Accepted non-canonical signature encoding")
+		}
+	})
+}
+
+func FuzzDeserializeBlissPublicKey(f *testing.F) {
+	for _, key := range fuzzKeys(f) {
+		enc, err := key.PublicKey().Serialize()
+		if err != nil {
+			f.Fatalf("Error in encoding public key: %s", err.Error())
+		}
+		f.Add(enc)
+		f.Add(enc[:len(enc)-1])
+	}
+	f.Add([]byte{})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		pub, err := DeserializeBlissPublicKey(data)
+		if err != nil {
+			return
+		}
+		if enc, err := pub.Serialize(); err != nil || !bytes.Equal(enc, data) {
+			t.Fatalf("Public key encoding is not canonical")
+		}
+	})
+}
+
+func FuzzDeserializeBlissPrivateKey(f *testing.F) {
+	for _, key := range fuzzKeys(f) {
+		enc, err := key.Serialize()
+		if err != nil {
+			f.Fatalf("Error in encoding private key: %s", err.Error())
+		}
+		f.Add(enc)
+		f.Add(enc[:len(enc)-1])
+	}
+	f.Add([]byte{})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		key, err := DeserializeBlissPrivateKey(data)
+		if err != nil {
+			return
+		}
+		if enc, err := key.Serialize(); err != nil || !bytes.Equal(enc, data) {
+			t.Fatalf("Private key encoding is not canonical")
+		}
+	})
+}
+
+func FuzzKeyRoundTrip(f *testing.F) {
+	for i := 0; i <= 4; i++ {
+		f.Add(uint8(i), []byte{byte(i)})
+	}
+	f.Fuzz(func(t *testing.T, version uint8, seed []byte) {
+		key, err := GeneratePrivateKey(int(version%5), fuzzEntropy(t, seed))
+		if err != nil {
+			return
+		}
+		enc, err := key.Serialize()
+		if err != nil {
+			t.Fatalf("Error in encoding private key: %s", err.Error())
+		}
+		priv, err := DeserializeBlissPrivateKey(enc)
+		if err != nil {
+			t.Fatalf("Error in decoding private key: %s", err.Error())
+		}
+		if !reflect.DeepEqual(key, priv) {
+			t.Fatalf("Different private key decoded")
+		}
+		enc, err = key.PublicKey().Serialize()
+		if err != nil {
+			t.Fatalf("Error in encoding public key: %s", err.Error())
+		}
+		pub, err := DeserializeBlissPublicKey(enc)
+		if err != nil {
+			t.Fatalf("Error in decoding public key: %s", err.Error())
+		}
+		if !reflect.DeepEqual(key.PublicKey(), pub) {
+			t.Fatalf("Different public key decoded")
+		}
+	})
+}
+
+func FuzzSignatureRoundTrip(f *testing.F) {
+	keys := fuzzKeys(f)
+	for i := 0; i <= 4; i++ {
+		f.Add(uint8(i), []byte{byte(i)}, []byte("Hello world"))
+	}
+	f.Fuzz(func(t *testing.T, version uint8, seed []byte, msg []byte) {
+		key := keys[version%5]
+		sig, err := key.Sign(msg, fuzzEntropy(t, seed))
+		if err != nil {
+			t.Fatalf("Failed to generate signature: %s", err.Error())
+		}
+		enc, err := sig.Serialize()
+		if err != nil {
+			t.Fatalf("Failed to encode signature: %s", err.Error())
+		}
+		tmp, err := DeserializeBlissSignature(enc)
+		if err != nil {
+			t.Fatalf("Error in decoding signature: %s", err.Error())
+		}
+		if !reflect.DeepEqual(sig, tmp) {
+			t.Fatalf("Different signature decoded")
+		}
+		if err = verified(key.PublicKey().Verify(msg, tmp)); err != nil {
+			t.Fatalf("Failed to verify decoded signature: %s", err.Error())
+		}
+	})
+}
@@ -15,10 +15,16 @@ import (
 // The two polynomials s1 and s2 are sparse polynomials with small coefficients
 // the polynomial a is actually the public key stored for efficiency reason.
 // a = -s2/s1
+// seed is the seed the key is generated from by GenerateKeyFromSeed, or nil.
+// locked is the locked memory holding s1, s2 and seed after LockMemory.
+// stats is the sink of the signing attempts set by SetSignStats, or nil.
 type BlissPrivateKey struct {
-	s1 *poly.PolyArray
-	s2 *poly.PolyArray
-	a  *poly.PolyArray
+	s1     *poly.PolyArray
+	s2     *poly.PolyArray
+	a      *poly.PolyArray
+	seed   []byte
+	locked []byte
+	stats  SignStatsSink
 }
 
 // The data structure for bliss public key.
@@ -35,58 +41,44 @@ type BlissPublicKey struct {
 // 2. Repeat step 1 until f is invertible.
 // 3. The private key is (s1,s2) = (f,2g-1)
 // 4. The public key is a = -s2/s1.
-// The key pair is stored in a Private Key structure.
+// The key pair is stored in a Private Key structure. It is
+// GeneratePrivateKeyWithOptions with the given entropy and the default
+// maximal number of attempts.
 func GeneratePrivateKey(version int, entropy *sampler.Entropy) (*BlissPrivateKey, error) {
-	// Generate g first, since g is not required to be invertible
-	// so there is no risk of repeat the sampling.
-	s2 := poly.UniformPoly(version, entropy)
-	if s2 == nil {
-		return nil, fmt.Errorf("Failed to generate uniform polynomial g")
+	if entropy == nil {
+		return nil, fmt.Errorf("Failed to generate private key: nil entropy")
 	}
-	// s2 = 2g-1
-	s2.ScalarMul(2)
-	s2.GetData()[0] -= 1
+	return GeneratePrivateKeyWithOptions(version, &KeyGenOptions{Entropy: entropy})
+}
 
+// Compute the public key a = NTT(-s2/s1) from the private key. An error
+// wrapping ErrNotInvertible is returned if s1 is not invertible.
+func publicPoly(s1, s2 *poly.PolyArray) (*poly.PolyArray, error) {
 	// Prepare s2 in NTT form
 	t, err := s2.NTT()
 	if err != nil {
 		return nil, err
 	}
-
-	for j := 0; j < 4; j++ {
-		// Now we sample f
-		s1 := poly.UniformPoly(version, entropy)
-		if s1 == nil {
-			return nil, fmt.Errorf("Failed to generate uniform polynomial f")
-		}
-		// Apply the NTT
-		u, err := s1.NTT()
-		if err != nil {
-			return nil, err
-		}
-		u, err = u.InvertAsNTT()
-		// If f is invertible, repeat the sampling.
-		if err != nil {
-			continue
-		}
-		// t = NTT(s2/s1)
-		t.MulModQ(u)
-		// t = INTT(NTT(s2/s1)) = s2/s1
-		t, err = t.INTT()
-		if err != nil {
-			return nil, err
-		}
-		// Negate t: t = -s2/s1
-		t.ScalarMulModQ(-1)
-		// a = NTT(-s2/s1)
-		a, err := t.NTT()
-		if err != nil {
-			return nil, err
-		}
-		key := BlissPrivateKey{s1, s2, a}
-		return &key, nil
+	// Apply the NTT
+	u, err := s1.NTT()
+	if err != nil {
+		return nil, err
 	}
-	return nil, fmt.Errorf("Failed to generate invertible polynomial")
+	u, err = u.InvertAsNTT()
+	if err != nil {
+		return nil, err
+	}
+	// t = NTT(s2/s1)
+	t.MulModQ(u)
+	// t = INTT(NTT(s2/s1)) = s2/s1
+	t, err = t.INTT()
+	if err != nil {
+		return nil, err
+	}
+	// Negate t: t = -s2/s1
+	t.ScalarMulModQ(-1)
+	// a = NTT(-s2/s1)
+	return t.NTT()
 }
 
 // Retrieve a copy of the BLISS public key from the private key.
@@ -96,7 +88,7 @@ func (privateKey *BlissPrivateKey) PublicKey() *BlissPublicKey {
 
 // Retrieve the BLISS parameter set from the BLISS private key.
 func (privateKey *BlissPrivateKey) Param() *params.BlissBParam {
-	return privateKey.s1.Param()
+	return privateKey.a.Param()
 }
 
 // Retrieve the BLISS parameter set from the BLISS public key.
@@ -106,6 +98,9 @@ func (publicKey *BlissPublicKey) Param() *params.BlissBParam {
 
 // Get the human readable string of a BLISS private key.
 func (privateKey *BlissPrivateKey) String() string {
+	if privateKey.destroyed() {
+		return fmt.Sprintf("{destroyed,a:%s}", privateKey.a.String())
+	}
 	return fmt.Sprintf("{s1:%s,s2:%s,a:%s}",
 		privateKey.s1.String(), privateKey.s2.String(), privateKey.a.String())
 }
@@ -120,27 +115,52 @@ func (publicKey *BlissPublicKey) String() string {
 // 3 bits. So we store f=s1 and g = (s2+1)/2 in 6*n bits, and compress them
 // into bytes array by a bit packer. The data is then prefixed by a byte
 // specifying the BLISS version.
-func (privateKey *BlissPrivateKey) Serialize() []byte {
+// An error wrapping ErrMalformedEncoding is returned if a coefficient is out
+// of range.
+func (privateKey *BlissPrivateKey) Serialize() ([]byte, error) {
+	if privateKey.destroyed() {
+		return nil, ErrKeyDestroyed
+	}
 	packer := huffman.NewBitPacker()
 	n := privateKey.Param().N
 	s1data := privateKey.s1.GetData()
 	s2data := privateKey.s2.GetData()
 	for i := 0; i < int(n); i++ {
-		packer.WriteBits(uint64(s1data[i]+2), 3)
+		if err := writeKeyCoefficient(packer, s1data[i]); err != nil {
+			return nil, err
+		}
+	}
+	if err := writeKeyCoefficient(packer, (s2data[0]+1)/2); err != nil {
+		return nil, err
 	}
-	packer.WriteBits(uint64((s2data[0]+1)/2)+2, 3)
 	for i := 1; i < int(n); i++ {
-		packer.WriteBits(uint64(s2data[i]/2+2), 3)
+		if err := writeKeyCoefficient(packer, s2data[i]/2); err != nil {
+			return nil, err
+		}
 	}
 	ret := []byte{byte(privateKey.Param().Version)}
-	return append(ret, packer.Data()...)
+	return append(ret, packer.Data()...), nil
+}
+
+// Write a coefficient of f or g in {-2,-1,0,1,2} as the coefficient plus 2
+// in 3 bits.
+func writeKeyCoefficient(packer *huffman.BitPacker, x int32) error {
+	if x < -2 || x > 2 {
+		return fmt.Errorf("Invalid private key coefficient %d: %w", x, ErrMalformedEncoding)
+	}
+	return packer.WriteBits(uint64(x+2), 3)
 }
 
 // Deserialize a BLISS private key from binary form.
+// The data must be exactly as produced by Serialize, and the key must pass
+// Validate.
 func DeserializeBlissPrivateKey(data []byte) (*BlissPrivateKey, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("Empty private key data: %w", ErrMalformedEncoding)
+	}
 	s1, err := poly.New(int(data[0]))
 	if err != nil {
-		return nil, fmt.Errorf("Error in generating new polyarray: %s", err.Error())
+		return nil, fmt.Errorf("Unknown BLISS version %d: %w", data[0], ErrMalformedEncoding)
 	}
 	s2, err := poly.NewPolyArray(s1.Param())
 	if err != nil {
@@ -148,52 +168,64 @@ func DeserializeBlissPrivateKey(data []byte) (*BlissPrivateKey, error) {
 	}
 
 	n := s1.Param().N
+	size := 1 + int(6*n+7)/8
+	if len(data) != size {
+		return nil, fmt.Errorf("Invalid private key length %d, expect %d: %w",
+			len(data), size, ErrMalformedEncoding)
+	}
+	// Wipe the decoded coefficients on every error path.
+	var key *BlissPrivateKey
+	defer func() {
+		if key == nil {
+			s1.Wipe()
+			s2.Wipe()
+		}
+	}()
 	unpacker := huffman.NewBitUnpacker(data[1:], 6*n)
 	s1data := s1.GetData()
 	s2data := s2.GetData()
 	for i := 0; i < int(n); i++ {
-		bits, err := unpacker.ReadBits(3)
+		bits, err := readKeyCoefficient(unpacker)
 		if err != nil {
 			return nil, err
 		}
 		s1data[i] = int32(bits) - 2
 	}
-	bits, err := unpacker.ReadBits(3)
+	bits, err := readKeyCoefficient(unpacker)
 	if err != nil {
 		return nil, err
 	}
 	s2data[0] = (int32(bits)-2)*2 - 1
 	for i := 1; i < int(n); i++ {
-		bits, err := unpacker.ReadBits(3)
+		bits, err := readKeyCoefficient(unpacker)
 		if err != nil {
 			return nil, err
 		}
 		s2data[i] = (int32(bits) - 2) * 2
 	}
-	t, err := s2.NTT()
-	if err != nil {
-		return nil, err
-	}
-	u, err := s1.NTT()
+	a, err := publicPoly(s1, s2)
 	if err != nil {
 		return nil, err
 	}
-	u, err = u.InvertAsNTT()
-	if err != nil {
+	candidate := &BlissPrivateKey{s1, s2, a, nil, nil, nil}
+	if err := candidate.Validate(); err != nil {
 		return nil, err
 	}
-	t.MulModQ(u)
-	t, err = t.INTT()
+	key = candidate
+	return key, nil
+}
+
+// Read a coefficient of f or g stored in 3 bits, which must be the
+// coefficient plus 2, for a coefficient in {-2,-1,0,1,2}.
+func readKeyCoefficient(unpacker *huffman.BitUnpacker) (uint64, error) {
+	bits, err := unpacker.ReadBits(3)
 	if err != nil {
-		return nil, err
+		return 0, fmt.Errorf("%s: %w", err.Error(), ErrMalformedEncoding)
 	}
-	t.ScalarMulModQ(-1)
-	a, err := t.NTT()
-	if err != nil {
-		return nil, err
+	if bits > 4 {
+		return 0, fmt.Errorf("Invalid private key coefficient %d: %w", int(bits)-2, ErrMalformedEncoding)
 	}
-	key := BlissPrivateKey{s1, s2, a}
-	return &key, nil
+	return bits, nil
 }
 
 // Serialize the Bliss Public Key into binary form.
@@ -201,32 +233,56 @@ func DeserializeBlissPrivateKey(data []byte) (*BlissPrivateKey, error) {
 // bits into a byte array. The coefficients of a are uniformly random in
 // [0,q), so compression is not considered. Each coefficient takes approximate
 // ceil(log(q)) bits. The data is prefixed by a byte of version number.
-func (publicKey *BlissPublicKey) Serialize() []byte {
+// An error wrapping ErrMalformedEncoding is returned if a coefficient is not
+// in [0,q).
+func (publicKey *BlissPublicKey) Serialize() ([]byte, error) {
 	qbit := publicKey.Param().Qbits
+	q := publicKey.Param().Q
 	n := publicKey.Param().N
 	packer := huffman.NewBitPacker()
 	adata := publicKey.a.GetData()
 	for i := 0; i < int(n); i++ {
-		packer.WriteBits(uint64(adata[i]), qbit)
+		if adata[i] < 0 || uint32(adata[i]) >= q {
+			return nil, fmt.Errorf("Invalid public key coefficient %d, expect < %d: %w",
+				adata[i], q, ErrMalformedEncoding)
+		}
+		if err := packer.WriteBits(uint64(adata[i]), qbit); err != nil {
+			return nil, err
+		}
 	}
 	ret := []byte{byte(publicKey.Param().Version)}
-	return append(ret, packer.Data()...)
+	return append(ret, packer.Data()...), nil
 }
 
 // Deserialize a BLISS public key from binary form.
+// The data must be exactly as produced by Serialize, and every coefficient of
+// a must be in [0,q).
 func DeserializeBlissPublicKey(data []byte) (*BlissPublicKey, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("Empty public key data: %w", ErrMalformedEncoding)
+	}
 	a, err := poly.New(int(data[0]))
 	if err != nil {
-		return nil, fmt.Errorf("Error in generating new polyarray: %s", err.Error())
+		return nil, fmt.Errorf("Unknown BLISS version %d: %w", data[0], ErrMalformedEncoding)
 	}
 	n := a.Param().N
+	q := a.Param().Q
 	qbit := a.Param().Qbits
+	size := 1 + int(n*qbit+7)/8
+	if len(data) != size {
+		return nil, fmt.Errorf("Invalid public key length %d, expect %d: %w",
+			len(data), size, ErrMalformedEncoding)
+	}
 	unpacker := huffman.NewBitUnpacker(data[1:], n*qbit)
 	adata := a.GetData()
 	for i := 0; i < int(n); i++ {
 		bits, err := unpacker.ReadBits(qbit)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("%s: %w", err.Error(), ErrMalformedEncoding)
+		}
+		if bits >= uint64(q) {
+			return nil, fmt.Errorf("Invalid public key coefficient %d, expect < %d: %w",
+				bits, q, ErrMalformedEncoding)
 		}
 		adata[i] = int32(bits)
 	}
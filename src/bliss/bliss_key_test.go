@@ -86,7 +86,10 @@ func TestKeySerialization(t *testing.T) {
 
 		{
 			pub := key.PublicKey()
-			enc := pub.Serialize()
+			enc, err := pub.Serialize()
+			if err != nil {
+				t.Errorf("Error in encoding public key: %s", err.Error())
+			}
 			fmt.Printf("Size of public key for BLISS-%d: %d bytes (%d bits)\n", i, len(enc), len(enc)*8)
 			tmp, err := DeserializeBlissPublicKey(enc)
 			if err != nil {
@@ -98,7 +101,10 @@ func TestKeySerialization(t *testing.T) {
 		}
 
 		{
-			enc := key.Serialize()
+			enc, err := key.Serialize()
+			if err != nil {
+				t.Errorf("Error in encoding private key: %s", err.Error())
+			}
 			fmt.Printf("Size of Private key for BLISS-%d: %d bytes (%d bits)\n", i, len(enc), len(enc)*8)
 			tmp, err := DeserializeBlissPrivateKey(enc)
 			if err != nil {
@@ -0,0 +1,86 @@
+package bliss
+
+import (
+	"errors"
+	"fmt"
+	"poly"
+	"sampler"
+)
+
+// The number of attempts of GeneratePrivateKeyWithOptions if
+// KeyGenOptions.MaxAttempts is zero.
+const DefaultKeyGenAttempts = 32
+
+// The options of GeneratePrivateKeyWithOptions. The zero value makes at most
+// DefaultKeyGenAttempts attempts with entropy seeded by crypto/rand.
+type KeyGenOptions struct {
+	// The maximal number of sampled candidate keys. Zero means
+	// DefaultKeyGenAttempts.
+	MaxAttempts int
+	// The entropy to draw the randomness from. If nil, the entropy is seeded
+	// by crypto/rand.Reader.
+	Entropy *sampler.Entropy
+}
+
+// The error wrapped by GeneratePrivateKeyWithOptions when no candidate key is
+// accepted within the maximal number of attempts.
+var ErrKeyGenAttempts = errors.New("Key generation attempts exhausted")
+
+// The BLISS Key Generation procedure with options. Every attempt samples f
+// for the same g, and is rejected if f is not invertible. No norm
+// check is needed: the greedy choice of the signs of c bounds |S*c'|^2 by
+// kappa*(|s1|^2+|s2|^2), which is at most M for every key of the version, so
+// every returned key can sign. opts may be nil for the default options.
+func GeneratePrivateKeyWithOptions(version int, opts *KeyGenOptions) (*BlissPrivateKey, error) {
+	if opts == nil {
+		opts = &KeyGenOptions{}
+	}
+	maxAttempts := opts.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultKeyGenAttempts
+	}
+	entropy := opts.Entropy
+	if entropy == nil {
+		rnd, err := readHedge(nil)
+		if err != nil {
+			return nil, err
+		}
+		entropy, err = sampler.NewEntropy(rnd)
+		wipeBytes(rnd)
+		if err != nil {
+			return nil, err
+		}
+		defer entropy.Destroy()
+	}
+
+	// Generate g first, since g is not required to be invertible
+	// so it is kept while only f is rejected.
+	s2, err := poly.UniformPoly(version, entropy)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to generate uniform polynomial g: %s", err.Error())
+	}
+	// s2 = 2g-1
+	s2.ScalarMul(2)
+	s2.GetData()[0] -= 1
+	for j := 0; j < maxAttempts; j++ {
+		s1, err := poly.UniformPoly(version, entropy)
+		if err != nil {
+			s2.Wipe()
+			return nil, fmt.Errorf("Failed to generate uniform polynomial f: %s", err.Error())
+		}
+		a, err := publicPoly(s1, s2)
+		// If f is not invertible, repeat the sampling.
+		if errors.Is(err, ErrNotInvertible) {
+			s1.Wipe()
+			continue
+		}
+		if err != nil {
+			s1.Wipe()
+			s2.Wipe()
+			return nil, err
+		}
+		return &BlissPrivateKey{s1, s2, a, nil, nil, nil}, nil
+	}
+	s2.Wipe()
+	return nil, fmt.Errorf("Failed to generate private key in %d attempts: %w", maxAttempts, ErrKeyGenAttempts)
+}
@@ -0,0 +1,85 @@
+package bliss
+
+import (
+	"errors"
+	"sampler"
+	"testing"
+)
+
+func TestGeneratePrivateKeyWithOptions(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy1, _ := sampler.NewEntropy(seed)
+		entropy2, _ := sampler.NewEntropy(seed)
+		key1, err := GeneratePrivateKey(i, entropy1)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+			continue
+		}
+		key2, err := GeneratePrivateKeyWithOptions(i, &KeyGenOptions{Entropy: entropy2})
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+			continue
+		}
+		if !key1.PublicKey().Equal(key2.PublicKey()) {
+			t.Errorf("Different private key from options for version %d", i)
+		}
+
+		// Every generated key can sign.
+		for j := 0; j < 20; j++ {
+			key, err := GeneratePrivateKeyWithOptions(i, &KeyGenOptions{Entropy: entropy1})
+			if err != nil {
+				t.Errorf("Error in generating private key: %s", err.Error())
+				continue
+			}
+			msg := []byte{byte(i), byte(j)}
+			sig, err := key.Sign(msg, entropy1)
+			if err != nil {
+				t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+				continue
+			}
+			err = verified(key.PublicKey().Verify(msg, sig))
+			if err != nil {
+				t.Errorf("Failed to verify signature for version %d: %s", i, err.Error())
+			}
+		}
+
+		// The key of the largest norm, with s2[0] = 2g[0]-1 of the largest
+		// absolute value, has kappa*(|s1|^2+|s2|^2) = M and still signs.
+		s2 := key1.s2.ScalarTimes(1)
+		data := s2.GetData()
+		largest := int32(2)
+		if key1.Param().Nz2 == 0 {
+			largest = 1
+		}
+		for j := 1; j < len(data); j++ {
+			if data[j] == 2*largest || data[j] == -2*largest {
+				data[0], data[j] = -2*largest-1, data[0]+1
+				break
+			}
+		}
+		key, err := NewPrivateKeyFromPolys(key1.s1, s2)
+		if err != nil && !errors.Is(err, ErrNotInvertible) {
+			t.Errorf("Key of largest norm rejected for version %d: %s", i, err.Error())
+		}
+		norm := int64(key1.s1.Norm2() + s2.Norm2())
+		if M := int64(key1.Param().M); norm*int64(key1.Param().Kappa) != M {
+			t.Errorf("Wrong largest norm for version %d: expect %d, got %d", i, M, norm*int64(key1.Param().Kappa))
+		}
+		if err == nil {
+			if _, err := key.Sign([]byte("Hello world"), entropy1); err != nil {
+				t.Errorf("Failed to sign with key of largest norm for version %d: %s", i, err.Error())
+			}
+		}
+	}
+
+	if _, err := GeneratePrivateKeyWithOptions(0, nil); err != nil {
+		t.Errorf("Error in generating private key: %s", err.Error())
+	}
+	if _, err := GeneratePrivateKey(0, nil); err == nil {
+		t.Errorf("Generating private key without entropy should fail")
+	}
+}
@@ -0,0 +1,59 @@
+//go:build linux
+// +build linux
+
+package bliss
+
+import (
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// Move the secret polynomials s1,s2 and the seed of the private key into
+// anonymous pages locked by mlock, so that they are never written to swap.
+// The old storage is wiped. The pages are released by Destroy. Locking a key
+// twice is harmless. It fails if the RLIMIT_MEMLOCK of the process is
+// exhausted. Like Destroy, it must not run concurrently with any use of the
+// key.
+func (privateKey *BlissPrivateKey) LockMemory() error {
+	if privateKey.destroyed() {
+		return ErrKeyDestroyed
+	}
+	if privateKey.locked != nil {
+		return nil
+	}
+	n := len(privateKey.s1.GetData())
+	size := 8*n + len(privateKey.seed)
+	mem, err := syscall.Mmap(-1, 0, size, syscall.PROT_READ|syscall.PROT_WRITE,
+		syscall.MAP_ANON|syscall.MAP_PRIVATE)
+	if err != nil {
+		return fmt.Errorf("Failed to map memory: %s", err.Error())
+	}
+	if err := syscall.Mlock(mem); err != nil {
+		syscall.Munmap(mem)
+		return fmt.Errorf("Failed to lock memory: %s", err.Error())
+	}
+
+	coeffs := unsafe.Slice((*int32)(unsafe.Pointer(&mem[0])), 2*n)
+	privateKey.s1.MoveTo(coeffs[:n])
+	privateKey.s2.MoveTo(coeffs[n:])
+	if privateKey.seed != nil {
+		seed := mem[8*n:]
+		copy(seed, privateKey.seed)
+		wipeBytes(privateKey.seed)
+		privateKey.seed = seed
+	}
+	privateKey.locked = mem
+	return nil
+}
+
+// Release the locked pages of LockMemory, wiping them first.
+func (privateKey *BlissPrivateKey) unlockMemory() {
+	if privateKey.locked == nil {
+		return
+	}
+	wipeBytes(privateKey.locked)
+	syscall.Munlock(privateKey.locked)
+	syscall.Munmap(privateKey.locked)
+	privateKey.locked = nil
+}
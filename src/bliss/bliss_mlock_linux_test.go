@@ -0,0 +1,56 @@
+package bliss
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+func TestLockMemory(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := bytes.Repeat([]byte{byte(i)}, SeedSize)
+		key, err := GenerateKeyFromSeed(i, seed)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+			continue
+		}
+		unlocked, _ := GenerateKeyFromSeed(i, seed)
+		if err := key.LockMemory(); err != nil {
+			t.Skipf("Memory cannot be locked: %s", err.Error())
+		}
+		if err := key.LockMemory(); err != nil {
+			t.Errorf("Error in locking memory twice: %s", err.Error())
+		}
+		start := uintptr(unsafe.Pointer(&key.locked[0]))
+		end := start + uintptr(len(key.locked))
+		for _, p := range []uintptr{
+			uintptr(unsafe.Pointer(&key.s1.GetData()[0])),
+			uintptr(unsafe.Pointer(&key.s2.GetData()[0])),
+			uintptr(unsafe.Pointer(&key.seed[0])),
+		} {
+			if p < start || p >= end {
+				t.Errorf("Private key not in locked memory for version %d", i)
+			}
+		}
+		if !reflect.DeepEqual(key.s1, unlocked.s1) || !reflect.DeepEqual(key.s2, unlocked.s2) ||
+			!bytes.Equal(key.Seed(), seed) {
+			t.Errorf("Private key changed by locking for version %d", i)
+		}
+		sig1, err := key.SignDeterministic([]byte("Hello world"))
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+		}
+		sig2, _ := unlocked.SignDeterministic([]byte("Hello world"))
+		if !reflect.DeepEqual(sig1, sig2) {
+			t.Errorf("Different signature from locked key for version %d", i)
+		}
+		key.Destroy()
+		if key.locked != nil {
+			t.Errorf("Locked memory not released for version %d", i)
+		}
+		if err := key.LockMemory(); err == nil {
+			t.Errorf("Locking destroyed key should fail")
+		}
+	}
+}
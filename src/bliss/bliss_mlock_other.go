@@ -0,0 +1,17 @@
+//go:build !linux
+// +build !linux
+
+package bliss
+
+import (
+	"fmt"
+	"runtime"
+)
+
+// Locked memory is only available on Linux, see bliss_mlock_linux.go.
+func (privateKey *BlissPrivateKey) LockMemory() error {
+	return fmt.Errorf("Locked memory is not supported on %s", runtime.GOOS)
+}
+
+// There is no locked memory to release.
+func (privateKey *BlissPrivateKey) unlockMemory() {}
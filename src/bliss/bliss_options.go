@@ -0,0 +1,112 @@
+package bliss
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/sha3"
+	"sampler"
+)
+
+// The options of SignContext. The zero value signs by the fast samplers,
+// without a limit of restarts, in the hedged mode with crypto/rand.
+type SignOptions struct {
+	// The maximal number of restarts of the rejection sampling. Zero means
+	// no limit.
+	MaxRestarts int
+	// Use the constant-time samplers of SignAgainstSideChannel instead of
+	// the fast samplers of Sign.
+	ConstantTime bool
+	// The entropy to draw the randomness from. If nil, the entropy is
+	// derived as by SignHedged with crypto/rand.Reader.
+	Entropy *sampler.Entropy
+	// The sink receiving the outcome of every attempt. If nil, the sink of
+	// the key set by SetSignStats is used.
+	Stats SignStatsSink
+}
+
+// The error wrapped by SignAbortedError when the restart limit is reached.
+var ErrRestartLimit = errors.New("Restart limit reached")
+
+// A SignAbortedError is returned by SignContext when it gives up before a
+// signature is accepted. Err is ErrRestartLimit if the restart limit is
+// reached, or the error of the context if it is cancelled or its deadline
+// is exceeded, so errors.Is can tell the two cases apart.
+type SignAbortedError struct {
+	Attempts int
+	Err      error
+}
+
+// Get the description of the aborted signing.
+func (e *SignAbortedError) Error() string {
+	return fmt.Sprintf("Signing aborted after %d attempts: %s", e.Attempts, e.Err.Error())
+}
+
+// Retrieve the cause of the aborted signing.
+func (e *SignAbortedError) Unwrap() error {
+	return e.Err
+}
+
+// The BLISS signature generation algorithm, which gives up when the context
+// is done or the restart limit in opts is reached. The context is checked
+// before every attempt of the rejection sampling. opts may be nil for the
+// default options.
+func (key *BlissPrivateKey) SignContext(ctx context.Context, msg []byte, opts *SignOptions) (*BlissSignature, error) {
+	hash := sha3.Sum512(msg)
+	return key.signContext(ctx, hash[:], opts)
+}
+
+// The bounded BLISS signature generation algorithm, given the message hash
+// that is fed into computeC.
+func (key *BlissPrivateKey) signContext(ctx context.Context, hash []byte, opts *SignOptions) (*BlissSignature, error) {
+	if opts == nil {
+		opts = &SignOptions{}
+	}
+	entropy := opts.Entropy
+	if entropy == nil {
+		rnd, err := readHedge(nil)
+		if err != nil {
+			return nil, err
+		}
+		defer wipeBytes(rnd)
+		secret, err := key.nonceKey()
+		if err != nil {
+			return nil, err
+		}
+		entropy, err = nonceEntropy(&secret, hash, rnd)
+		wipeBytes(secret[:])
+		if err != nil {
+			return nil, err
+		}
+		defer entropy.Destroy()
+	}
+	s, err := sampler.New(key.Param().Version, entropy)
+	if err != nil {
+		return nil, err
+	}
+	scratch, err := newSignScratch(key.Param())
+	if err != nil {
+		return nil, err
+	}
+	for attempts := 0; ; attempts++ {
+		if err := ctx.Err(); err != nil {
+			return nil, &SignAbortedError{attempts, err}
+		}
+		if opts.MaxRestarts > 0 && attempts > opts.MaxRestarts {
+			return nil, &SignAbortedError{attempts, ErrRestartLimit}
+		}
+		var c *commitment
+		if opts.ConstantTime {
+			c, err = key.commitAgainstSideChannel(s, entropy, scratch)
+		} else {
+			c, err = key.commit(s, entropy, scratch.y1, scratch.y2)
+		}
+		if err != nil {
+			return nil, err
+		}
+		sig, err := key.respond(c, hash, opts.Stats)
+		if err != nil || sig != nil {
+			return sig, err
+		}
+	}
+}
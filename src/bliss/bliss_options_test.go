@@ -0,0 +1,101 @@
+package bliss
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sampler"
+	"testing"
+)
+
+func TestSignContext(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+		}
+
+		pub := key.PublicKey()
+		msg := []byte("Hello world")
+		sig, err := key.SignContext(context.Background(), msg, nil)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		err = verified(pub.Verify(msg, sig))
+		if err != nil {
+			t.Errorf("Failed to verify signature for version %d: %s", i, err.Error())
+		}
+
+		for _, ct := range []bool{false, true} {
+			entropy1, _ := sampler.NewEntropy(seed)
+			entropy2, _ := sampler.NewEntropy(seed)
+			var sig1 *BlissSignature
+			if ct {
+				sig1, err = key.SignAgainstSideChannel(msg, entropy1)
+			} else {
+				sig1, err = key.Sign(msg, entropy1)
+			}
+			if err != nil {
+				t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+				continue
+			}
+			sig2, err := key.SignContext(context.Background(), msg,
+				&SignOptions{ConstantTime: ct, Entropy: entropy2})
+			if err != nil {
+				t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+				continue
+			}
+			if !reflect.DeepEqual(sig1, sig2) {
+				t.Errorf("Different signature from SignContext for version %d", i)
+			}
+		}
+	}
+}
+
+func TestSignContextAborted(t *testing.T) {
+	seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+	entropy, err := sampler.NewEntropy(seed)
+	if err != nil {
+		t.Errorf("Error in initializing entropy: %s", err.Error())
+	}
+	key, err := GeneratePrivateKey(0, entropy)
+	if err != nil {
+		t.Errorf("Error in generating private key: %s", err.Error())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = key.SignContext(ctx, []byte("Hello world"), nil)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expect cancelled signing, got %v", err)
+	}
+	var aborted *SignAbortedError
+	if !errors.As(err, &aborted) || aborted.Attempts != 0 {
+		t.Errorf("Expect SignAbortedError after 0 attempts, got %v", err)
+	}
+
+	// With a single restart allowed, some of the messages must hit the limit.
+	limited := 0
+	for j := 0; j < 32; j++ {
+		_, err = key.SignContext(context.Background(), []byte{byte(j)},
+			&SignOptions{MaxRestarts: 1, Entropy: entropy})
+		if errors.Is(err, ErrRestartLimit) {
+			limited++
+		} else if err != nil {
+			t.Errorf("Unexpected error: %s", err.Error())
+		}
+	}
+	if limited == 0 {
+		t.Errorf("Restart limit never reached")
+	}
+}
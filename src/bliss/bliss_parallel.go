@@ -0,0 +1,154 @@
+package bliss
+
+import (
+	"encoding/binary"
+	"golang.org/x/crypto/sha3"
+	"math"
+	"runtime"
+	"sampler"
+	"sync"
+	"sync/atomic"
+)
+
+// The domain separation tag of the hash deriving the entropy of each attempt
+// of the parallel signing.
+const parallelAttemptTag = "BLISS-B parallel attempt"
+
+// Derive the entropy of the k'th signing attempt from the root seed.
+// The seed of the attempt is SHA3-512(tag||root||k), with k in 8 bytes little
+// endian, so every attempt draws from an independent stream.
+func attemptEntropy(root []byte, k uint64) (*sampler.Entropy, error) {
+	data := append([]byte(parallelAttemptTag), root...)
+	defer wipeBytes(data)
+	var index [8]byte
+	binary.LittleEndian.PutUint64(index[:], k)
+	data = append(data, index[:]...)
+	seed := sha3.Sum512(data)
+	defer wipeBytes(seed[:])
+	return sampler.NewEntropy(seed[:])
+}
+
+// The outcome of an attempt of the parallel signing, held back until it is
+// known whether the sequential signer would have run the attempt.
+type attemptOutcome struct {
+	recorded bool
+	normV    int32
+	outcome  Rejection
+}
+
+// Record the outcome of the attempt.
+func (o *attemptOutcome) RecordAttempt(normV int32, outcome Rejection) {
+	o.recorded, o.normV, o.outcome = true, normV, outcome
+}
+
+// The BLISS signature generation algorithm running the rejection sampling
+// attempts on workers goroutines. If workers is not positive,
+// runtime.NumCPU() workers are used.
+// A root seed of 64 bytes is drawn from entropy, and the k'th attempt draws
+// from its own entropy derived from the root seed and k. The attempts are
+// handed out in increasing order of k, and the accepted attempt of the
+// smallest k is returned, exactly as if the attempts were run one after
+// another. So the signature has the same distribution as the sequential
+// signer, and only depends on entropy, not on workers or the scheduling.
+// Once an attempt is accepted, no attempt of larger k is started. Only the
+// attempts up to the returned one are recorded in the sink of SetSignStats,
+// in increasing order of k.
+func (key *BlissPrivateKey) SignParallel(msg []byte, entropy *sampler.Entropy, workers int) (*BlissSignature, error) {
+	hash := sha3.Sum512(msg)
+	return key.signParallel(hash[:], entropy, workers)
+}
+
+// The parallel BLISS signature generation algorithm, given the message hash
+// that is fed into computeC.
+func (key *BlissPrivateKey) signParallel(hash []byte, entropy *sampler.Entropy, workers int) (*BlissSignature, error) {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	root := make([]byte, sampler.SHA_512_DIGEST_LENGTH)
+	defer wipeBytes(root)
+	for i := 0; i < len(root); i++ {
+		root[i] = entropy.Char()
+	}
+	proto, err := sampler.New(key.Param().Version, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var next uint64
+	var mu sync.Mutex
+	// The smallest attempt that has finished with a signature or an error.
+	best := uint64(math.MaxUint64)
+	var bestSig *BlissSignature
+	var bestErr error
+	outcomes := make(map[uint64]attemptOutcome)
+	finish := func(k uint64, sig *BlissSignature, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if k < best {
+			best, bestSig, bestErr = k, sig, err
+		}
+	}
+	cancelled := func(k uint64) bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return k > best
+	}
+
+	scratches := make([]*signScratch, workers)
+	for w := 0; w < workers; w++ {
+		scratches[w], err = newSignScratch(key.Param())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(scratch *signScratch) {
+			defer wg.Done()
+			defer scratch.wipe()
+			for {
+				k := atomic.AddUint64(&next, 1) - 1
+				if cancelled(k) {
+					return
+				}
+				e, err := attemptEntropy(root, k)
+				if err != nil {
+					finish(k, nil, err)
+					return
+				}
+				c, err := key.commit(proto.WithEntropy(e), e, scratch.y1, scratch.y2)
+				if err != nil {
+					e.Destroy()
+					finish(k, nil, err)
+					return
+				}
+				if cancelled(k) {
+					c.wipe()
+					e.Destroy()
+					return
+				}
+				var o attemptOutcome
+				sig, err := key.respond(c, hash, &o)
+				e.Destroy()
+				if o.recorded {
+					mu.Lock()
+					outcomes[k] = o
+					mu.Unlock()
+				}
+				if err != nil || sig != nil {
+					finish(k, sig, err)
+					return
+				}
+			}
+		}(scratches[w])
+	}
+	wg.Wait()
+	for k := uint64(0); k <= best && k < next; k++ {
+		if o, ok := outcomes[k]; ok {
+			recordAttempt(key.stats, o.normV, o.outcome)
+		}
+	}
+	return bestSig, bestErr
+}
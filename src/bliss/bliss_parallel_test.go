@@ -0,0 +1,83 @@
+package bliss
+
+import (
+	"reflect"
+	"runtime"
+	"sampler"
+	"testing"
+)
+
+func TestSignParallel(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+		}
+
+		pub := key.PublicKey()
+		msg := []byte("Hello world")
+		var first *BlissSignature
+		for _, workers := range []int{1, 2, 8, 0} {
+			e, _ := sampler.NewEntropy(seed)
+			sig, err := key.SignParallel(msg, e, workers)
+			if err != nil {
+				t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+				continue
+			}
+			err = verified(pub.Verify(msg, sig))
+			if err != nil {
+				t.Errorf("Failed to verify signature for version %d: %s", i, err.Error())
+			}
+			if first == nil {
+				first = sig
+			} else if !reflect.DeepEqual(first, sig) {
+				t.Errorf("Signature depends on the number of workers for version %d", i)
+			}
+		}
+	}
+}
+
+func TestSignParallelStats(t *testing.T) {
+	seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+	entropy, err := sampler.NewEntropy(seed)
+	if err != nil {
+		t.Errorf("Error in initializing entropy: %s", err.Error())
+	}
+	key, err := GeneratePrivateKey(1, entropy)
+	if err != nil {
+		t.Errorf("Error in generating private key: %s", err.Error())
+		return
+	}
+	// The recorded attempts are those of the sequential signer, whatever the
+	// number of workers.
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+	var expect *SignStats
+	for _, workers := range []int{1, 8} {
+		stats := &SignStats{}
+		key.SetSignStats(stats)
+		entropy, _ := sampler.NewEntropy(seed)
+		for j := 0; j < 20; j++ {
+			if _, err := key.SignParallel([]byte{byte(j)}, entropy, workers); err != nil {
+				t.Errorf("Failed to generate signature: %s", err.Error())
+			}
+		}
+		if stats.Count(Accepted) != 20 {
+			t.Errorf("Wrong number of accepted attempts with %d workers: %d", workers, stats.Count(Accepted))
+		}
+		if expect == nil {
+			expect = stats
+		} else if stats.Attempts() != expect.Attempts() || stats.MaxNormV() != expect.MaxNormV() {
+			t.Errorf("Different attempts recorded with %d workers: %d, expect %d",
+				workers, stats.Attempts(), expect.Attempts())
+		}
+	}
+}
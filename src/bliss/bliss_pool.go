@@ -0,0 +1,155 @@
+package bliss
+
+import (
+	"fmt"
+	"golang.org/x/crypto/sha3"
+	"io"
+	"poly"
+	"sampler"
+	"sync"
+)
+
+// A CommitmentPool splits the signing into an offline and an online phase.
+// Most of the signing cost, i.e. sampling y1,y2 and computing v = a*y1 + y2,
+// does not depend on the message. A background goroutine precomputes such
+// commitments into a bounded pool, and the online Sign only computes the
+// challenge, GreedySC and the rejection sampling.
+// Each commitment is taken from the pool by exactly one signing attempt and
+// then dropped, whether the attempt is accepted or rejected, so no
+// commitment is ever used twice.
+// A CommitmentPool is safe for concurrent use by multiple goroutines.
+type CommitmentPool struct {
+	key         *BlissPrivateKey
+	sampler     *sampler.Sampler
+	rand        io.Reader
+	commitments chan *commitment
+	done        chan struct{}
+	closeOnce   sync.Once
+	wg          sync.WaitGroup
+	err         error
+}
+
+// Create a commitment pool holding at most size commitments for the given
+// private key, and start filling it in the background. The entropy of each
+// commitment is created from a seed of 64 bytes read from rand. If rand is
+// nil, crypto/rand.Reader is used. rand is only read by the background
+// goroutine.
+// The pool must be closed by Close to stop the background goroutine.
+func NewCommitmentPool(key *BlissPrivateKey, size int, rand io.Reader) (*CommitmentPool, error) {
+	if size <= 0 {
+		return nil, fmt.Errorf("Invalid pool size %d", size)
+	}
+	s, err := sampler.New(key.Param().Version, nil)
+	if err != nil {
+		return nil, err
+	}
+	pool := &CommitmentPool{
+		key:         key,
+		sampler:     s,
+		rand:        rand,
+		commitments: make(chan *commitment, size),
+		done:        make(chan struct{}),
+	}
+	pool.wg.Add(1)
+	go pool.fill()
+	return pool, nil
+}
+
+// Compute a new commitment with its own entropy. The entropy is destroyed
+// together with the commitment by dropCommitment.
+func (pool *CommitmentPool) newCommitment() (*commitment, error) {
+	entropy, err := newEntropyFromReader(pool.rand)
+	if err != nil {
+		return nil, err
+	}
+	y1, err := poly.NewPolyArray(pool.key.Param())
+	if err != nil {
+		entropy.Destroy()
+		return nil, err
+	}
+	y2, err := poly.NewPolyArray(pool.key.Param())
+	if err != nil {
+		entropy.Destroy()
+		return nil, err
+	}
+	c, err := pool.key.commit(pool.sampler.WithEntropy(entropy), entropy, y1, y2)
+	if err != nil {
+		y1.Wipe()
+		y2.Wipe()
+		entropy.Destroy()
+		return nil, err
+	}
+	return c, nil
+}
+
+// Wipe a commitment of the pool and destroy its entropy, once it is used by
+// a signing attempt or dropped.
+func dropCommitment(c *commitment) {
+	c.wipe()
+	c.entropy.Destroy()
+}
+
+// The background procedure filling the pool until it is closed or an error
+// occurs. The error is recorded before the channel of commitments is closed,
+// so the online signing can report it.
+func (pool *CommitmentPool) fill() {
+	defer pool.wg.Done()
+	defer close(pool.commitments)
+	for {
+		c, err := pool.newCommitment()
+		if err != nil {
+			pool.err = err
+			return
+		}
+		select {
+		case pool.commitments <- c:
+		case <-pool.done:
+			dropCommitment(c)
+			return
+		}
+	}
+}
+
+// Return the number of commitments ready in the pool.
+func (pool *CommitmentPool) Len() int {
+	return len(pool.commitments)
+}
+
+// Stop filling the pool, and drop the commitments left in it. Signing with
+// a closed pool fails.
+func (pool *CommitmentPool) Close() {
+	pool.closeOnce.Do(func() {
+		close(pool.done)
+	})
+	pool.wg.Wait()
+	for c := range pool.commitments {
+		dropCommitment(c)
+	}
+}
+
+// The online BLISS signature generation algorithm. Every signing attempt
+// takes a new commitment from the pool, waiting for the background goroutine
+// if the pool is empty.
+func (pool *CommitmentPool) Sign(msg []byte) (*BlissSignature, error) {
+	hash := sha3.Sum512(msg)
+	return pool.sign(hash[:])
+}
+
+// The online BLISS signature generation algorithm, given the message hash
+// that is fed into computeC.
+func (pool *CommitmentPool) sign(hash []byte) (*BlissSignature, error) {
+	for {
+		c, ok := <-pool.commitments
+		if !ok {
+			if pool.err != nil {
+				return nil, fmt.Errorf("Failed to fill commitment pool: %s", pool.err.Error())
+			}
+			return nil, fmt.Errorf("Commitment pool closed")
+		}
+		sig, err := pool.key.respond(c, hash, nil)
+		c.entropy.Destroy()
+		if err != nil || sig != nil {
+			return sig, err
+		}
+	}
+}
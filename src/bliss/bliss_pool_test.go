@@ -0,0 +1,124 @@
+package bliss
+
+import (
+	"bytes"
+	"sampler"
+	"sync"
+	"testing"
+)
+
+func TestCommitmentPool(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+		}
+		pool, err := NewCommitmentPool(key, 4, nil)
+		if err != nil {
+			t.Errorf("Error in creating commitment pool: %s", err.Error())
+			continue
+		}
+
+		pub := key.PublicKey()
+		var wg sync.WaitGroup
+		for j := 0; j < 4; j++ {
+			wg.Add(1)
+			go func(j int) {
+				defer wg.Done()
+				msg := []byte{byte(i), byte(j)}
+				sig, err := pool.Sign(msg)
+				if err != nil {
+					t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+					return
+				}
+				err = verified(pub.Verify(msg, sig))
+				if err != nil {
+					t.Errorf("Failed to verify signature for version %d: %s", i, err.Error())
+				}
+			}(j)
+		}
+		wg.Wait()
+
+		pool.Close()
+		pool.Close()
+		if pool.Len() != 0 {
+			t.Errorf("Commitments left in closed pool for version %d", i)
+		}
+		_, err = pool.Sign([]byte("Hello world"))
+		if err == nil {
+			t.Errorf("Signing with closed pool should fail for version %d", i)
+		}
+	}
+}
+
+func TestCommitmentPoolRandomFailure(t *testing.T) {
+	seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+	entropy, err := sampler.NewEntropy(seed)
+	if err != nil {
+		t.Errorf("Error in initializing entropy: %s", err.Error())
+	}
+	key, err := GeneratePrivateKey(0, entropy)
+	if err != nil {
+		t.Errorf("Error in generating private key: %s", err.Error())
+	}
+	pool, err := NewCommitmentPool(key, 2, bytes.NewReader(make([]byte, 10)))
+	if err != nil {
+		t.Errorf("Error in creating commitment pool: %s", err.Error())
+	}
+	defer pool.Close()
+	_, err = pool.Sign([]byte("Hello world"))
+	if err == nil {
+		t.Errorf("Signing with failed random source should fail")
+	}
+}
+
+func TestCommitmentPoolDestroysEntropy(t *testing.T) {
+	seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+	entropy, err := sampler.NewEntropy(seed)
+	if err != nil {
+		t.Errorf("Error in initializing entropy: %s", err.Error())
+	}
+	key, err := GeneratePrivateKey(0, entropy)
+	if err != nil {
+		t.Errorf("Error in generating private key: %s", err.Error())
+	}
+	for _, closing := range []bool{false, true} {
+		// The random source suffices for exactly one commitment.
+		pool, err := NewCommitmentPool(key, 2, bytes.NewReader(make([]byte, 64)))
+		if err != nil {
+			t.Errorf("Error in creating commitment pool: %s", err.Error())
+			continue
+		}
+		pool.wg.Wait()
+		c, ok := <-pool.commitments
+		if !ok {
+			t.Errorf("No commitment in pool")
+			continue
+		}
+		commitments := make(chan *commitment, 1)
+		commitments <- c
+		close(commitments)
+		pool.commitments = commitments
+		if !closing {
+			pool.Sign([]byte("Hello world"))
+		}
+		pool.Close()
+		destroyed := func() (panicked bool) {
+			defer func() { panicked = recover() != nil }()
+			c.entropy.Char()
+			return false
+		}()
+		if !destroyed {
+			t.Errorf("Entropy of commitment not destroyed, closing = %v", closing)
+		}
+	}
+}
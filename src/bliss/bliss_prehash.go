@@ -0,0 +1,48 @@
+package bliss
+
+import (
+	"crypto"
+	"fmt"
+	"golang.org/x/crypto/sha3"
+	"sampler"
+)
+
+// Compute the message hash fed into computeC for a digest computed elsewhere
+// by the hash function h.
+// In the pure mode, the input of computeC is SHA3-512(msg)||u. In the
+// pre-hash mode, the input is SHA3-512(digest)||mode||h||u, where mode and h
+// take one byte each. The two inputs always differ in length, so a signature
+// made in one mode never verifies in the other, and the identity of h is
+// bound to the signature.
+func prehash(h crypto.Hash, digest []byte) ([]byte, error) {
+	if h == 0 || h > crypto.BLAKE2b_512 {
+		return nil, fmt.Errorf("Unsupported hash function %d", h)
+	}
+	if len(digest) != h.Size() {
+		return nil, fmt.Errorf("Wrong digest length for %s, expected %d, got %d",
+			h.String(), h.Size(), len(digest))
+	}
+	hash := sha3.Sum512(digest)
+	return append(hash[:], prehashMode, byte(h)), nil
+}
+
+// The BLISS signature generation algorithm in pre-hash mode. The message is
+// given by its digest under the hash function h, instead of the message
+// itself.
+func (key *BlissPrivateKey) SignDigest(h crypto.Hash, digest []byte, entropy *sampler.Entropy) (*BlissSignature, error) {
+	hash, err := prehash(h, digest)
+	if err != nil {
+		return nil, err
+	}
+	return key.sign(hash, entropy)
+}
+
+// The BLISS signature verification algorithm in pre-hash mode. The signature
+// must have been generated by SignDigest with the same hash function h.
+func (key *BlissPublicKey) VerifyDigest(h crypto.Hash, digest []byte, sig *BlissSignature) (bool, error) {
+	hash, err := prehash(h, digest)
+	if err != nil {
+		return false, err
+	}
+	return key.verify(hash, sig)
+}
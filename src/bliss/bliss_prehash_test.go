@@ -0,0 +1,94 @@
+package bliss
+
+import (
+	"crypto"
+	"crypto/sha256"
+	"golang.org/x/crypto/sha3"
+	"sampler"
+	"testing"
+)
+
+func TestSignVerifyDigest(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+		}
+
+		pub := key.PublicKey()
+		msg := []byte("Hello world")
+		digest256 := sha256.Sum256(msg)
+		digest512 := sha3.Sum512(msg)
+
+		sig, err := key.SignDigest(crypto.SHA256, digest256[:], entropy)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		err = verified(pub.VerifyDigest(crypto.SHA256, digest256[:], sig))
+		if err != nil {
+			t.Errorf("Failed to verify signature for version %d: %s", i, err.Error())
+		}
+		err = verified(pub.Verify(msg, sig))
+		if err == nil {
+			t.Errorf("Pre-hash signature verified in pure mode for version %d", i)
+		}
+
+		sig, err = key.SignDigest(crypto.SHA3_512, digest512[:], entropy)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		err = verified(pub.VerifyDigest(crypto.SHA3_512, digest512[:], sig))
+		if err != nil {
+			t.Errorf("Failed to verify signature for version %d: %s", i, err.Error())
+		}
+		err = verified(pub.VerifyDigest(crypto.SHA512, digest512[:], sig))
+		if err == nil {
+			t.Errorf("Signature verified with different hash function for version %d", i)
+		}
+		err = verified(pub.Verify(msg, sig))
+		if err == nil {
+			t.Errorf("Pre-hash signature verified in pure mode for version %d", i)
+		}
+
+		sig, err = key.Sign(msg, entropy)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		err = verified(pub.VerifyDigest(crypto.SHA3_512, digest512[:], sig))
+		if err == nil {
+			t.Errorf("Pure signature verified in pre-hash mode for version %d", i)
+		}
+	}
+}
+
+func TestSignDigestInvalid(t *testing.T) {
+	seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+	entropy, err := sampler.NewEntropy(seed)
+	if err != nil {
+		t.Errorf("Error in initializing entropy: %s", err.Error())
+	}
+	key, err := GeneratePrivateKey(0, entropy)
+	if err != nil {
+		t.Errorf("Error in generating private key: %s", err.Error())
+	}
+	_, err = key.SignDigest(crypto.Hash(0), make([]byte, 32), entropy)
+	if err == nil {
+		t.Errorf("Signing without hash function should fail")
+	}
+	_, err = key.SignDigest(crypto.SHA256, make([]byte, 31), entropy)
+	if err == nil {
+		t.Errorf("Signing digest of wrong length should fail")
+	}
+}
@@ -0,0 +1,142 @@
+package bliss
+
+import (
+	"crypto"
+	"fmt"
+	"golang.org/x/crypto/sha3"
+	"params"
+	"poly"
+)
+
+// A PreparedPublicKey holds everything the verification algorithm computes
+// from the public key alone: the parameter set, the polynomial a scaled for
+// the multiplication by 2/(q+2), and the twiddle tables of the NTT.
+// Repeated verifications against a prepared key only do the per-signature
+// work. A PreparedPublicKey is never modified, so it is safe for concurrent
+// use by multiple goroutines.
+type PreparedPublicKey struct {
+	key    *BlissPublicKey
+	param  *params.BlissBParam
+	aq     *poly.PolyArray
+	tables *poly.NTTTables
+}
+
+// Precompute the per-key part of the verification algorithm.
+// The verification algorithm computes v = 2*(1/(q+2))*z1*a mod 2q. Since
+// 2*x mod 2q = 2*(x mod q), this equals 2*(z1*aq mod q), where
+// aq = a*(1/(q+2)) mod q can be computed once for a public key.
+func (publicKey *BlissPublicKey) Prepare() (*PreparedPublicKey, error) {
+	param := publicKey.Param()
+	tables, err := poly.NewNTTTables(param)
+	if err != nil {
+		return nil, err
+	}
+	aq := publicKey.a.ScalarTimesModQ(int32(param.OneQ2 % param.Q))
+	return &PreparedPublicKey{publicKey, param, aq, tables}, nil
+}
+
+// Retrieve the BLISS public key that was prepared.
+func (prepared *PreparedPublicKey) PublicKey() *BlissPublicKey {
+	return prepared.key
+}
+
+// Retrieve the BLISS parameter set of the prepared public key.
+func (prepared *PreparedPublicKey) Param() *params.BlissBParam {
+	return prepared.param
+}
+
+// The BLISS signature verification algorithm, as by BlissPublicKey.Verify.
+func (prepared *PreparedPublicKey) Verify(msg []byte, sig *BlissSignature) (bool, error) {
+	hash := sha3.Sum512(msg)
+	return prepared.verify(hash[:], sig)
+}
+
+// The BLISS signature verification algorithm in pre-hash mode, as by
+// BlissPublicKey.VerifyDigest.
+func (prepared *PreparedPublicKey) VerifyDigest(h crypto.Hash, digest []byte, sig *BlissSignature) (bool, error) {
+	hash, err := prehash(h, digest)
+	if err != nil {
+		return false, err
+	}
+	return prepared.verify(hash, sig)
+}
+
+// The BLISS signature verification algorithm with domain separation, as by
+// BlissPublicKey.VerifyWithContext.
+func (prepared *PreparedPublicKey) VerifyWithContext(msg, ctx []byte, sig *BlissSignature) (bool, error) {
+	hash, err := contextHash(msg, ctx)
+	if err != nil {
+		return false, err
+	}
+	return prepared.verify(hash, sig)
+}
+
+// The BLISS signature verification algorithm, given the message hash that is
+// fed into computeC.
+// A rejected signature gives false without error, following the contract of
+// BlissPublicKey.Verify.
+func (prepared *PreparedPublicKey) verify(hash []byte, sig *BlissSignature) (bool, error) {
+	failure, err := prepared.check(hash, sig)
+	if err != nil {
+		return false, err
+	}
+	if failure == VerifyVersionMismatch {
+		return false, failure.Err()
+	}
+	return failure == VerifyOK, nil
+}
+
+// Run the checks of the verification algorithm, and report the first check
+// that fails. An error is returned if the signature is malformed.
+func (prepared *PreparedPublicKey) check(hash []byte, sig *BlissSignature) (VerifyFailure, error) {
+	param := prepared.param
+	if sig == nil || sig.z1 == nil {
+		return VerifyOK, fmt.Errorf("Missing signature: %w", ErrInvalidSignature)
+	}
+	if param.Version != sig.z1.Param().Version {
+		return VerifyVersionMismatch, nil
+	}
+	if err := sig.checkShape(param); err != nil {
+		return VerifyOK, err
+	}
+	if failure := checkNorms(sig, param); failure != VerifyOK {
+		return failure, nil
+	}
+	z1, z2, indices := sig.z1, sig.z2, sig.c
+	v, err := z1.MultiplyNTTWithTables(prepared.aq, prepared.tables)
+	if err != nil {
+		return VerifyOK, err
+	}
+	v.ScalarMul(2)
+	vdata := v.GetData()
+	for i := 0; i < len(indices); i++ {
+		qq := param.Q * param.OneQ2
+		vdata[indices[i]] = v.NumMod2Q(vdata[indices[i]] + int32(qq))
+	}
+	v = v.DropBits().Add(z2).ModP()
+	indicesp := computeC(param.Kappa, v, hash)
+	if len(indicesp) != len(indices) {
+		return VerifyIndicesMismatch, nil
+	}
+	for i := 0; i < len(indices); i++ {
+		if indices[i] != indicesp[i] {
+			return VerifyIndicesMismatch, nil
+		}
+	}
+	return VerifyOK, nil
+}
+
+// Run the norm checks of the verification algorithm on z1 and z2.
+func checkNorms(sig *BlissSignature, param *params.BlissBParam) VerifyFailure {
+	if sig.z1.MaxNorm() > int32(param.Binf) {
+		return VerifyZ1MaxNorm
+	}
+	tz2 := sig.z2.Mul2d()
+	if tz2.MaxNorm() > int32(param.Binf) {
+		return VerifyZ2MaxNorm
+	}
+	if sig.z1.Norm2()+tz2.Norm2() > int32(param.Bl2) {
+		return VerifyL2Norm
+	}
+	return VerifyOK
+}
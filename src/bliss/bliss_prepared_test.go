@@ -0,0 +1,92 @@
+package bliss
+
+import (
+	"params"
+	"sampler"
+	"testing"
+)
+
+func TestPreparedPublicKey(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+		}
+		prepared, err := key.PublicKey().Prepare()
+		if err != nil {
+			t.Errorf("Error in preparing public key: %s", err.Error())
+			continue
+		}
+
+		for j := 0; j < 4; j++ {
+			msg := []byte{byte(i), byte(j)}
+			sig, err := key.Sign(msg, entropy)
+			if err != nil {
+				t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+				continue
+			}
+			err = verified(prepared.Verify(msg, sig))
+			if err != nil {
+				t.Errorf("Failed to verify signature for version %d: %s", i, err.Error())
+			}
+			err = verified(prepared.Verify([]byte("Hello world"), sig))
+			if err == nil {
+				t.Errorf("Verified signature of wrong message for version %d", i)
+			}
+		}
+
+		ctx := []byte("release-manifest-v1")
+		sig, err := key.SignWithContext([]byte("Hello world"), ctx, entropy)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		err = verified(prepared.VerifyWithContext([]byte("Hello world"), ctx, sig))
+		if err != nil {
+			t.Errorf("Failed to verify signature for version %d: %s", i, err.Error())
+		}
+	}
+}
+
+func benchPreparedVerify(b *testing.B, version int) {
+	seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+	for i := 0; i < len(seed); i++ {
+		seed[i] = uint8(i % 8)
+	}
+	entropy, err := sampler.NewEntropy(seed)
+	if err != nil {
+		b.Errorf("Error in initializing entropy: %s", err.Error())
+	}
+
+	key, err := GeneratePrivateKey(version, entropy)
+	if err != nil {
+		b.Errorf("Error in generating private key: %s", err.Error())
+	}
+
+	prepared, err := key.PublicKey().Prepare()
+	if err != nil {
+		b.Errorf("Error in preparing public key: %s", err.Error())
+	}
+	msg := []byte("Hello world")
+	sig, err := key.Sign(msg, entropy)
+	for i := 0; i < b.N; i++ {
+		prepared.Verify(msg, sig)
+	}
+}
+
+func BenchmarkPreparedVerifyBliss0(b *testing.B) {
+	benchPreparedVerify(b, params.BLISS_B_0)
+}
+
+func BenchmarkPreparedVerifyBliss4(b *testing.B) {
+	benchPreparedVerify(b, params.BLISS_B_4)
+}
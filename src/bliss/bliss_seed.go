@@ -0,0 +1,62 @@
+package bliss
+
+import (
+	"fmt"
+	"golang.org/x/crypto/sha3"
+	"sampler"
+)
+
+// The domain separation tag of the hash deriving the entropy of key
+// generation from a seed.
+const keySeedTag = "BLISS-B key seed"
+
+// The lengths of the seeds accepted by GenerateKeyFromSeed.
+const (
+	SeedSize     = 32
+	LongSeedSize = 64
+)
+
+// Derive the entropy of key generation from the seed. The seed of the
+// entropy is SHA3-512(tag||version||len(seed)||seed), where version and
+// len(seed) take one byte each, so different versions never share the
+// sampled f,g.
+func seedEntropy(version int, seed []byte) (*sampler.Entropy, error) {
+	data := append([]byte(keySeedTag), byte(version), byte(len(seed)))
+	data = append(data, seed...)
+	defer wipeBytes(data)
+	digest := sha3.Sum512(data)
+	defer wipeBytes(digest[:])
+	return sampler.NewEntropy(digest[:])
+}
+
+// Generate the BLISS private key determined by a seed of SeedSize or
+// LongSeedSize bytes. The seed is expanded into the entropy of
+// GeneratePrivateKey, including the rejected candidates, so the same version
+// and seed always give the same key, and the seed is the whole secret to
+// store. The seed is kept in the key and returned by Seed.
+func GenerateKeyFromSeed(version int, seed []byte) (*BlissPrivateKey, error) {
+	if len(seed) != SeedSize && len(seed) != LongSeedSize {
+		return nil, fmt.Errorf("Invalid seed length, expected %d or %d, got %d",
+			SeedSize, LongSeedSize, len(seed))
+	}
+	entropy, err := seedEntropy(version, seed)
+	if err != nil {
+		return nil, err
+	}
+	defer entropy.Destroy()
+	key, err := GeneratePrivateKey(version, entropy)
+	if err != nil {
+		return nil, err
+	}
+	key.seed = append([]byte{}, seed...)
+	return key, nil
+}
+
+// Retrieve a copy of the seed the private key is generated from by
+// GenerateKeyFromSeed, or nil if the key is not generated from a seed.
+func (privateKey *BlissPrivateKey) Seed() []byte {
+	if privateKey.seed == nil {
+		return nil
+	}
+	return append([]byte{}, privateKey.seed...)
+}
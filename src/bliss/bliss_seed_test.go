@@ -0,0 +1,86 @@
+package bliss
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestGenerateKeyFromSeed(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		for _, size := range []int{SeedSize, LongSeedSize} {
+			seed := make([]byte, size)
+			for j := range seed {
+				seed[j] = byte(j % 8)
+			}
+			key, err := GenerateKeyFromSeed(i, seed)
+			if err != nil {
+				t.Errorf("Error in generating private key: %s", err.Error())
+				continue
+			}
+			if !bytes.Equal(key.Seed(), seed) {
+				t.Errorf("Wrong seed of key for version %d", i)
+			}
+			if err := key.Validate(); err != nil {
+				t.Errorf("Generated private key invalid for version %d: %s", i, err.Error())
+			}
+
+			tmp, err := GenerateKeyFromSeed(i, key.Seed())
+			if err != nil {
+				t.Errorf("Error in generating private key: %s", err.Error())
+				continue
+			}
+			if !key.PublicKey().Equal(tmp.PublicKey()) {
+				t.Errorf("Different key from the same seed for version %d", i)
+			}
+			enc1, _ := key.Serialize()
+			enc2, _ := tmp.Serialize()
+			if !bytes.Equal(enc1, enc2) {
+				t.Errorf("Different private key from the same seed for version %d", i)
+			}
+
+			seed[0] ^= 1
+			tmp, err = GenerateKeyFromSeed(i, seed)
+			if err != nil {
+				t.Errorf("Error in generating private key: %s", err.Error())
+				continue
+			}
+			if key.PublicKey().Equal(tmp.PublicKey()) {
+				t.Errorf("Same key from different seeds for version %d", i)
+			}
+		}
+	}
+
+	short, err := GenerateKeyFromSeed(0, make([]byte, SeedSize))
+	if err != nil {
+		t.Errorf("Error in generating private key: %s", err.Error())
+	}
+	long, err := GenerateKeyFromSeed(0, make([]byte, LongSeedSize))
+	if err != nil {
+		t.Errorf("Error in generating private key: %s", err.Error())
+	}
+	if short != nil && long != nil && short.PublicKey().Equal(long.PublicKey()) {
+		t.Errorf("Same key from seeds of different lengths")
+	}
+	for _, size := range []int{0, 16, 48, 65} {
+		if _, err := GenerateKeyFromSeed(0, make([]byte, size)); err == nil {
+			t.Errorf("Seed of %d bytes should be rejected", size)
+		}
+	}
+	seed := make([]byte, SeedSize)
+	key, _ := GenerateKeyFromSeed(0, seed)
+	if key != nil {
+		key.Seed()[0] = 1
+		if key.Seed()[0] != 0 {
+			t.Errorf("Seed of key modified through its copy")
+		}
+	}
+	if key != nil {
+		enc, _ := key.Serialize()
+		tmp, err := DeserializeBlissPrivateKey(enc)
+		if err != nil {
+			t.Errorf("Error in decoding private key: %s", err.Error())
+		} else if tmp.Seed() != nil {
+			t.Errorf("Deserialized key should not have a seed")
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package bliss
+
+import (
+	"bytes"
+	cryptorand "crypto/rand"
+	"errors"
+	"fmt"
+	"golang.org/x/crypto/sha3"
+	"io"
+)
+
+// The layout of a share of a private key:
+//
+//	magic       4 bytes  "BLSH"
+//	format      1 byte   keyShareFormat
+//	version     1 byte   the BLISS version
+//	kind        1 byte   shareOfKey or shareOfSeed
+//	threshold   1 byte   the number of shares to recover the key
+//	index       1 byte   the x coordinate of the share, in [1,255]
+//	fingerprint 32 bytes Fingerprint of the public key
+//	payload              the y coordinates, one for each byte of the secret
+//	checksum    4 bytes  SHA3-256 of everything above, truncated
+//
+// The secret is the seed of a key from GenerateKeyFromSeed, or the output of
+// Serialize otherwise.
+const (
+	keyShareMagic     = "BLSH"
+	keyShareFormat    = 1
+	shareOfKey        = 0
+	shareOfSeed       = 1
+	shareChecksumSize = 4
+	maxShares         = 255
+)
+
+// The offsets of the fields in the header of a share.
+const (
+	shareFormatSlot = len(keyShareMagic) + iota
+	shareVersionSlot
+	shareKindSlot
+	shareThresholdSlot
+	shareIndexSlot
+	shareFingerprintSlot
+	shareHeaderSize = shareFingerprintSlot + FingerprintSize
+)
+
+// The error wrapped when the shares are malformed, inconsistent, too few, or
+// recover a key that does not match their fingerprint.
+var ErrInvalidShare = errors.New("Invalid key share")
+
+// Multiply in GF(2^8) modulo x^8+x^4+x^3+x+1, without branches on the
+// operands.
+func gfMul(a, b byte) byte {
+	var p byte
+	for i := 0; i < 8; i++ {
+		p ^= -(b & 1) & a
+		a = (a << 1) ^ (-(a >> 7) & 0x1b)
+		b >>= 1
+	}
+	return p
+}
+
+// Invert a nonzero element of GF(2^8) as a^254.
+func gfInv(a byte) byte {
+	r := a
+	for i := 0; i < 6; i++ {
+		a = gfMul(a, a)
+		r = gfMul(r, a)
+	}
+	return gfMul(r, r)
+}
+
+// Split the BLISS private key into n shares, so that any threshold of them
+// recover it by CombinePrivateKeyShares and fewer reveal nothing about it.
+// Each byte of the secret is shared by Shamir's scheme over GF(2^8).
+// 2 <= threshold <= n <= 255.
+func SplitPrivateKey(key *BlissPrivateKey, n, threshold int) ([][]byte, error) {
+	return splitPrivateKey(key, n, threshold, cryptorand.Reader)
+}
+
+// The SplitPrivateKey procedure with the given randomness.
+func splitPrivateKey(key *BlissPrivateKey, n, threshold int, rand io.Reader) ([][]byte, error) {
+	if threshold < 2 || n < threshold || n > maxShares {
+		return nil, fmt.Errorf("Invalid sharing: %d of %d shares", threshold, n)
+	}
+	kind := byte(shareOfSeed)
+	secret := key.Seed()
+	if secret == nil {
+		kind = shareOfKey
+		var err error
+		secret, err = key.Serialize()
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer wipeBytes(secret)
+	fingerprint, err := key.PublicKey().Fingerprint()
+	if err != nil {
+		return nil, err
+	}
+
+	// coeffs[j] holds the coefficients of degree 1..threshold-1 of the
+	// polynomial sharing secret[j].
+	coeffs := make([]byte, len(secret)*(threshold-1))
+	if _, err := io.ReadFull(rand, coeffs); err != nil {
+		return nil, fmt.Errorf("Failed to read random coefficients: %s", err.Error())
+	}
+	defer wipeBytes(coeffs)
+	shares := make([][]byte, n)
+	for i := 0; i < n; i++ {
+		x := byte(i + 1)
+		share := append([]byte(keyShareMagic), keyShareFormat, byte(key.Param().Version),
+			kind, byte(threshold), x)
+		share = append(share, fingerprint...)
+		for j, s := range secret {
+			// Horner's rule from the coefficient of the highest degree.
+			c := coeffs[j*(threshold-1) : (j+1)*(threshold-1)]
+			var y byte
+			for k := len(c) - 1; k >= 0; k-- {
+				y = gfMul(y, x) ^ c[k]
+			}
+			share = append(share, gfMul(y, x)^s)
+		}
+		checksum := sha3.Sum256(share)
+		shares[i] = append(share, checksum[:shareChecksumSize]...)
+	}
+	return shares, nil
+}
+
+// Check the checksum and the header of a share, and return its payload.
+func parseShare(share []byte) ([]byte, error) {
+	if len(share) < shareHeaderSize+shareChecksumSize ||
+		string(share[:len(keyShareMagic)]) != keyShareMagic {
+		return nil, fmt.Errorf("Not a key share: %w", ErrInvalidShare)
+	}
+	body := share[:len(share)-shareChecksumSize]
+	checksum := sha3.Sum256(body)
+	if !bytes.Equal(checksum[:shareChecksumSize], share[len(body):]) {
+		return nil, fmt.Errorf("Wrong checksum of key share: %w", ErrInvalidShare)
+	}
+	if body[shareFormatSlot] != keyShareFormat {
+		return nil, fmt.Errorf("Unknown key share format %d: %w", body[shareFormatSlot], ErrInvalidShare)
+	}
+	if kind := body[shareKindSlot]; kind != shareOfKey && kind != shareOfSeed {
+		return nil, fmt.Errorf("Unknown kind of key share %d: %w", kind, ErrInvalidShare)
+	}
+	if body[shareThresholdSlot] < 2 || body[shareIndexSlot] == 0 {
+		return nil, fmt.Errorf("Invalid threshold %d or index %d of key share: %w",
+			body[shareThresholdSlot], body[shareIndexSlot], ErrInvalidShare)
+	}
+	return body[shareHeaderSize:], nil
+}
+
+// Recover the BLISS private key from at least threshold shares produced by
+// SplitPrivateKey. The shares must agree on everything but the index and the
+// payload, and the recovered key must match the fingerprint in the shares.
+// The errors wrap ErrInvalidShare.
+func CombinePrivateKeyShares(shares [][]byte) (*BlissPrivateKey, error) {
+	if len(shares) == 0 {
+		return nil, fmt.Errorf("No key share: %w", ErrInvalidShare)
+	}
+	payloads := make([][]byte, len(shares))
+	xs := make([]byte, len(shares))
+	first := shares[0]
+	for i, share := range shares {
+		payload, err := parseShare(share)
+		if err != nil {
+			return nil, err
+		}
+		// Only the index and the payload differ between the shares.
+		if len(share) != len(first) ||
+			!bytes.Equal(share[:shareIndexSlot], first[:shareIndexSlot]) ||
+			!bytes.Equal(share[shareFingerprintSlot:shareHeaderSize], first[shareFingerprintSlot:shareHeaderSize]) {
+			return nil, fmt.Errorf("Key share %d is from a different sharing: %w", i, ErrInvalidShare)
+		}
+		x := share[shareIndexSlot]
+		for j := 0; j < i; j++ {
+			if xs[j] == x {
+				return nil, fmt.Errorf("Repeated key share index %d: %w", x, ErrInvalidShare)
+			}
+		}
+		xs[i], payloads[i] = x, payload
+	}
+	threshold := int(first[shareThresholdSlot])
+	if len(shares) < threshold {
+		return nil, fmt.Errorf("Insufficient key shares, need %d, got %d: %w",
+			threshold, len(shares), ErrInvalidShare)
+	}
+	xs, payloads = xs[:threshold], payloads[:threshold]
+
+	// Lagrange interpolation at 0: secret = sum_i y_i * prod_{j!=i} x_j/(x_j-x_i),
+	// where subtraction is xor in GF(2^8).
+	secret := make([]byte, len(payloads[0]))
+	for i := range xs {
+		l := byte(1)
+		for j := range xs {
+			if i != j {
+				l = gfMul(l, gfMul(xs[j], gfInv(xs[j]^xs[i])))
+			}
+		}
+		for k, y := range payloads[i] {
+			secret[k] ^= gfMul(l, y)
+		}
+	}
+
+	defer wipeBytes(secret)
+	version := int(first[shareVersionSlot])
+	var key *BlissPrivateKey
+	var err error
+	if first[shareKindSlot] == shareOfSeed {
+		key, err = GenerateKeyFromSeed(version, secret)
+	} else {
+		key, err = DeserializeBlissPrivateKey(secret)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("Failed to recover private key: %s: %w", err.Error(), ErrInvalidShare)
+	}
+	fingerprint, err := key.PublicKey().Fingerprint()
+	if err != nil {
+		key.Destroy()
+		return nil, err
+	}
+	if key.Param().Version != version ||
+		!bytes.Equal(fingerprint, first[shareFingerprintSlot:shareHeaderSize]) {
+		key.Destroy()
+		return nil, fmt.Errorf("Recovered key does not match fingerprint: %w", ErrInvalidShare)
+	}
+	return key, nil
+}
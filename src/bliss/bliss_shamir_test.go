@@ -0,0 +1,96 @@
+package bliss
+
+import (
+	"errors"
+	"reflect"
+	"sampler"
+	"testing"
+)
+
+func TestGF256(t *testing.T) {
+	for a := 1; a < 256; a++ {
+		if gfMul(byte(a), gfInv(byte(a))) != 1 {
+			t.Errorf("Wrong inverse of %d", a)
+		}
+		if gfMul(byte(a), 1) != byte(a) || gfMul(byte(a), 0) != 0 {
+			t.Errorf("Wrong product of %d", a)
+		}
+	}
+	// 0x53 * 0xca = 1 in the AES field.
+	if gfMul(0x53, 0xca) != 1 {
+		t.Errorf("Wrong product 0x53 * 0xca = %#x", gfMul(0x53, 0xca))
+	}
+}
+
+func TestSplitPrivateKey(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+			continue
+		}
+		seeded, err := GenerateKeyFromSeed(i, seed)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+			continue
+		}
+
+		for _, k := range []*BlissPrivateKey{key, seeded} {
+			shares, err := SplitPrivateKey(k, 5, 3)
+			if err != nil {
+				t.Errorf("Error in splitting private key: %s", err.Error())
+				continue
+			}
+			for _, subset := range [][]int{{0, 1, 2}, {4, 2, 0}, {1, 3, 4}, {0, 1, 2, 3, 4}} {
+				picked := [][]byte{}
+				for _, j := range subset {
+					picked = append(picked, shares[j])
+				}
+				tmp, err := CombinePrivateKeyShares(picked)
+				if err != nil {
+					t.Errorf("Error in combining shares %v for version %d: %s", subset, i, err.Error())
+				} else if !reflect.DeepEqual(k, tmp) {
+					t.Errorf("Different private key combined from shares %v for version %d", subset, i)
+				}
+			}
+
+			_, err = CombinePrivateKeyShares(shares[:2])
+			if !errors.Is(err, ErrInvalidShare) {
+				t.Errorf("Expect ErrInvalidShare for too few shares, got %v", err)
+			}
+			_, err = CombinePrivateKeyShares([][]byte{shares[0], shares[1], shares[0]})
+			if !errors.Is(err, ErrInvalidShare) {
+				t.Errorf("Expect ErrInvalidShare for repeated shares, got %v", err)
+			}
+			bad := append([]byte{}, shares[1]...)
+			bad[shareHeaderSize] ^= 1
+			_, err = CombinePrivateKeyShares([][]byte{shares[0], bad, shares[2]})
+			if !errors.Is(err, ErrInvalidShare) {
+				t.Errorf("Expect ErrInvalidShare for corrupted share, got %v", err)
+			}
+		}
+
+		// Shares of different keys do not mix.
+		shares1, _ := SplitPrivateKey(key, 3, 2)
+		shares2, _ := SplitPrivateKey(seeded, 3, 2)
+		if _, err := CombinePrivateKeyShares([][]byte{shares1[0], shares2[1]}); !errors.Is(err, ErrInvalidShare) {
+			t.Errorf("Expect ErrInvalidShare for shares of different keys, got %v", err)
+		}
+	}
+
+	key, _ := GenerateKeyFromSeed(0, make([]byte, SeedSize))
+	for _, c := range [][2]int{{1, 1}, {3, 4}, {256, 2}, {0, 0}} {
+		if _, err := SplitPrivateKey(key, c[0], c[1]); err == nil {
+			t.Errorf("Sharing %d of %d should be rejected", c[1], c[0])
+		}
+	}
+
+}
@@ -4,6 +4,7 @@
 package bliss
 
 import (
+	"bytes"
 	"fmt"
 	"golang.org/x/crypto/sha3"
 	"huffman"
@@ -22,6 +23,13 @@ type BlissSignature struct {
 	c  []uint32
 }
 
+// The mode bytes that follow the message hash in the input of computeC, for
+// the signing modes other than the pure mode.
+const (
+	prehashMode byte = 1
+	contextMode byte = 2
+)
+
 // Get a human readable form of a BLISS signature.
 func (sig *BlissSignature) String() string {
 	return fmt.Sprintf("{z1:%s,z2:%s,c:%d}",
@@ -32,10 +40,14 @@ func (sig *BlissSignature) String() string {
 // digest to a challenge, i.e. an index set of size kappa in [0,n).
 // The cryptographic hash (in this case SHA3-512) of (u||hash) is used as the
 // random source to generate the indices.
+// An empty slice is returned if no kappa distinct indices are found after 256
+// tries, which the callers must check for.
 func computeC(kappa uint32, u *poly.PolyArray, hash []byte) []uint32 {
 	indices := make([]uint32, kappa)
 	data := u.GetData()
 	n := len(data)
+	// Copy the hash, so that the digest owned by the caller is never touched.
+	hash = append(make([]byte, 0, len(hash)+2*n), hash...)
 	for i := 0; i < n; i++ {
 		hash = append(hash, byte(data[i]&0xff))
 		hash = append(hash, byte((data[i]>>8)&0xff))
@@ -97,6 +109,13 @@ func computeC(kappa uint32, u *poly.PolyArray, hash []byte) []uint32 {
 // This algorithm is not the most optimized, but the result is sufficiently
 // satisfactory.
 func greedySc(indices []uint32, s1, s2 *poly.PolyArray) (v1, v2 *poly.PolyArray) {
+	return greedyScSigns(indices, s1, s2, nil)
+}
+
+// The GreedySC algorithm, which also stores into signs, if it is not nil,
+// the sign picked for each index: -1 if the shifted s is subtracted, and 1
+// if it is added.
+func greedyScSigns(indices []uint32, s1, s2 *poly.PolyArray, signs []int8) (v1, v2 *poly.PolyArray) {
 	n := s1.Param().N
 	v1, _ = poly.NewPolyArray(s1.Param())
 	v2, _ = poly.NewPolyArray(s2.Param())
@@ -113,6 +132,13 @@ func greedySc(indices []uint32, s1, s2 *poly.PolyArray) (v1, v2 *poly.PolyArray)
 		for i := n - index; i < n; i++ {
 			sign -= s1data[i]*v1data[index+i-n] + s2data[i]*v2data[index+i-n]
 		}
+		if signs != nil {
+			if sign > 0 {
+				signs[k] = -1
+			} else {
+				signs[k] = 1
+			}
+		}
 		if sign > 0 {
 			for i := uint32(0); i < n-index; i++ {
 				v1data[index+i] -= s1data[i]
@@ -138,19 +164,81 @@ func greedySc(indices []uint32, s1, s2 *poly.PolyArray) (v1, v2 *poly.PolyArray)
 
 // The BLISS signature generation algorithm.
 func (key *BlissPrivateKey) Sign(msg []byte, entropy *sampler.Entropy) (*BlissSignature, error) {
-	kappa := key.Param().Kappa
-	version := key.Param().Version
-	Binf := key.Param().Binf
-	Bl2 := key.Param().Bl2
-	M := key.Param().M
-	sampler, err := sampler.New(version, entropy)
+	hash := sha3.Sum512(msg)
+	return key.sign(hash[:], entropy)
+}
+
+// The scratch polynomials receiving the Gaussian samples of a signing
+// attempt. They are reused by all the attempts of a signature, and by Signer
+// across signatures.
+type signScratch struct {
+	y1     *poly.PolyArray
+	y2     *poly.PolyArray
+	y1beta *poly.PolyArray
+	y2beta *poly.PolyArray
+}
+
+// Allocate the scratch polynomials for the given parameter set.
+func newSignScratch(param *params.BlissBParam) (*signScratch, error) {
+	scratch := signScratch{}
+	for _, p := range []**poly.PolyArray{&scratch.y1, &scratch.y2, &scratch.y1beta, &scratch.y2beta} {
+		pa, err := poly.NewPolyArray(param)
+		if err != nil {
+			return nil, err
+		}
+		*p = pa
+	}
+	return &scratch, nil
+}
+
+// The BLISS signature generation algorithm, given the message hash that is
+// fed into computeC.
+func (key *BlissPrivateKey) sign(hash []byte, entropy *sampler.Entropy) (*BlissSignature, error) {
+	s, err := sampler.New(key.Param().Version, entropy)
 	if err != nil {
 		return nil, err
 	}
-	hash := sha3.Sum512(msg)
-restart:
-	y1 := poly.GaussPoly(version, sampler)
-	y2 := poly.GaussPoly(version, sampler)
+	scratch, err := newSignScratch(key.Param())
+	if err != nil {
+		return nil, err
+	}
+	return key.signWith(s, scratch, hash, entropy)
+}
+
+// The BLISS signature generation algorithm, with the sampler and the scratch
+// polynomials prepared by the caller. The sampler must draw its randomness
+// from entropy.
+func (key *BlissPrivateKey) signWith(sampler *sampler.Sampler, scratch *signScratch, hash []byte, entropy *sampler.Entropy) (*BlissSignature, error) {
+	for {
+		c, err := key.commit(sampler, entropy, scratch.y1, scratch.y2)
+		if err != nil {
+			return nil, err
+		}
+		sig, err := key.respond(c, hash, nil)
+		if err != nil || sig != nil {
+			return sig, err
+		}
+	}
+}
+
+// A commitment is the part of a signing attempt that does not depend on the
+// message: the Gaussian polynomials y1,y2, v = 2*(1/(q+2))*a*y1 + y2 mod 2q
+// and its compressed form dv fed into computeC. It keeps the sampler and the
+// entropy that produced it, and the rejection sampling of the attempt goes on
+// drawing randomness from them.
+type commitment struct {
+	y1      *poly.PolyArray
+	y2      *poly.PolyArray
+	v       *poly.PolyArray
+	dv      *poly.PolyArray
+	sampler *sampler.Sampler
+	entropy *sampler.Entropy
+}
+
+// Compute a commitment, sampling the Gaussian polynomials into y1 and y2.
+func (key *BlissPrivateKey) commit(sampler *sampler.Sampler, entropy *sampler.Entropy, y1, y2 *poly.PolyArray) (*commitment, error) {
+	poly.GaussPolyTo(y1, sampler)
+	poly.GaussPolyTo(y2, sampler)
 	v, err := y1.MultiplyNTT(key.a)
 	if err != nil {
 		return nil, err
@@ -160,17 +248,75 @@ restart:
 	v.Inc(y2)
 	v = v.Mod2Q()
 	dv := v.DropBits().ModP()
-	indices := computeC(kappa, dv, hash[:])
-	v1, v2 := greedySc(indices, key.s1, key.s2)
+	return &commitment{y1, y2, v, dv, sampler, entropy}, nil
+}
+
+// The intermediate values of a signing attempt, kept for the telemetry and
+// the transcript of the attempt.
+type attempt struct {
+	c       *commitment
+	indices []uint32
+	v1      *poly.PolyArray
+	v2      *poly.PolyArray
+	normV   int32
+	b       bool
+	outcome Rejection
+}
+
+// Complete a signing attempt from a commitment and the message hash fed into
+// computeC. A nil signature without error means that the attempt is
+// rejected, and the signing must restart with a new commitment.
+// The outcome of the attempt is recorded in stats, or in the sink of the key
+// set by SetSignStats if stats is nil.
+func (key *BlissPrivateKey) respond(c *commitment, hash []byte, stats SignStatsSink) (*BlissSignature, error) {
+	a := attempt{c: c}
+	// The commitment and (v1,v2) = (s1,s2)*c' are secret, and never used
+	// again whatever the outcome.
+	defer c.wipe()
+	sig, err := key.tryRespond(&a, hash)
+	defer a.v1.Wipe()
+	defer a.v2.Wipe()
+	if err != nil {
+		return nil, err
+	}
+	if stats == nil {
+		stats = key.stats
+	}
+	recordAttempt(stats, a.normV, a.outcome)
+	key.traceAttempt(&a, sig)
+	return sig, nil
+}
+
+// The rejection sampling of respond. The intermediate values and the
+// outcome are stored in a.
+func (key *BlissPrivateKey) tryRespond(a *attempt, hash []byte) (*BlissSignature, error) {
+	kappa := key.Param().Kappa
+	Binf := key.Param().Binf
+	Bl2 := key.Param().Bl2
+	M := key.Param().M
+	if key.destroyed() {
+		return nil, ErrKeyDestroyed
+	}
+	c := a.c
+	y1, y2, v := c.y1, c.y2, c.v
+	a.indices = computeC(kappa, c.dv, hash)
+	if len(a.indices) != int(kappa) {
+		return nil, fmt.Errorf("Failed to compute the challenge")
+	}
+	v1, v2 := greedySc(a.indices, key.s1, key.s2)
+	a.v1, a.v2 = v1, v2
 	normV := v1.Norm2() + v2.Norm2()
-	if M <= uint32(normV) {
+	a.normV = normV
+	if M < uint32(normV) {
 		return nil, fmt.Errorf("|v|^2 is larger than M")
 	}
-	if !sampler.SampleBerExp(M - uint32(normV)) {
-		goto restart
+	if !c.sampler.SampleBerExp(M - uint32(normV)) {
+		a.outcome = RejectBerExp
+		return nil, nil
 	}
 	var z1, z2 *poly.PolyArray
-	b := entropy.Bit()
+	b := c.entropy.Bit()
+	a.b = b
 	if b {
 		z1 = y1.Sub(v1)
 		z2 = y2.Sub(v2)
@@ -179,44 +325,92 @@ restart:
 		z2 = y2.Add(v2)
 	}
 	prodZV := z1.InnerProduct(v1) + z2.InnerProduct(v2)
-	if !sampler.SampleBerCosh(prodZV) {
-		goto restart
-	}
+	if !c.sampler.SampleBerCosh(prodZV) {
+		a.outcome = RejectBerCosh
+		z1.Wipe()
+		z2.Wipe()
+		return nil, nil
+	}
+	// The uncompressed z2 = y2 +- v2 is secret, only its compressed form
+	// is published.
+	defer z2.Wipe()
 	y1 = v.Sub(z2).Mod2Q().DropBits()
 	v = v.DropBits()
 	z2 = v.Sub(y1).BoundByP()
 	if z1.MaxNorm() > int32(Binf) {
-		goto restart
+		a.outcome = RejectZ1MaxNorm
+		z1.Wipe()
+		return nil, nil
 	}
 	y2 = z2.Mul2d()
 	if y2.MaxNorm() > int32(Binf) {
-		goto restart
+		a.outcome = RejectZ2MaxNorm
+		z1.Wipe()
+		return nil, nil
 	}
 	if z1.Norm2()+y2.Norm2() > int32(Bl2) {
-		goto restart
+		a.outcome = RejectL2Norm
+		z1.Wipe()
+		return nil, nil
 	}
-	return &BlissSignature{z1, z2, indices}, nil
+	a.outcome = Accepted
+	return &BlissSignature{z1, z2, a.indices}, nil
 }
 
 // The BLISS signature generation algorithm, which is supposed to be secure
 // against side-channel attacks.
 func (key *BlissPrivateKey) SignAgainstSideChannel(msg []byte, entropy *sampler.Entropy) (*BlissSignature, error) {
-	kappa := key.Param().Kappa
-	version := key.Param().Version
-	Binf := key.Param().Binf
-	Bl2 := key.Param().Bl2
-	M := key.Param().M
-	sampler, err := sampler.New(version, entropy)
+	hash := sha3.Sum512(msg)
+	return key.signAgainstSideChannel(hash[:], entropy)
+}
+
+// The side-channel resistant BLISS signature generation algorithm, given the
+// message hash that is fed into computeC.
+func (key *BlissPrivateKey) signAgainstSideChannel(hash []byte, entropy *sampler.Entropy) (*BlissSignature, error) {
+	s, err := sampler.New(key.Param().Version, entropy)
 	if err != nil {
 		return nil, err
 	}
-	hash := sha3.Sum512(msg)
-restart:
-	y1alpha := poly.GaussPolyAlpha(version, sampler)
-	y2alpha := poly.GaussPolyAlpha(version, sampler)
-	y1beta := poly.GaussPolyBeta(version, sampler)
-	y2beta := poly.GaussPolyBeta(version, sampler)
+	scratch, err := newSignScratch(key.Param())
+	if err != nil {
+		return nil, err
+	}
+	return key.signAgainstSideChannelWith(s, scratch, hash, entropy)
+}
+
+// The side-channel resistant BLISS signature generation algorithm, with the
+// sampler and the scratch polynomials prepared by the caller. The sampler
+// must draw its randomness from entropy.
+func (key *BlissPrivateKey) signAgainstSideChannelWith(sampler *sampler.Sampler, scratch *signScratch, hash []byte, entropy *sampler.Entropy) (*BlissSignature, error) {
+	for {
+		c, err := key.commitAgainstSideChannel(sampler, entropy, scratch)
+		if err != nil {
+			return nil, err
+		}
+		sig, err := key.respond(c, hash, nil)
+		if err != nil || sig != nil {
+			return sig, err
+		}
+	}
+}
+
+// Compute a commitment by the constant-time samplers, sampling each of the
+// Gaussian polynomials y1,y2 as the sum of an alpha part and a beta part.
+// The products with a are computed separately for the two parts, so the
+// result is the same as if y1 were multiplied at once.
+func (key *BlissPrivateKey) commitAgainstSideChannel(sampler *sampler.Sampler, entropy *sampler.Entropy, scratch *signScratch) (*commitment, error) {
+	y1alpha := scratch.y1
+	y2alpha := scratch.y2
+	y1beta := scratch.y1beta
+	y2beta := scratch.y2beta
+	poly.GaussPolyAlphaTo(y1alpha, sampler)
+	poly.GaussPolyAlphaTo(y2alpha, sampler)
+	poly.GaussPolyBetaTo(y1beta, sampler)
+	poly.GaussPolyBetaTo(y2beta, sampler)
 	valpha, err := y1alpha.MultiplyNTT(key.a)
+	if err != nil {
+		return nil, err
+	}
 	vbeta, err := y1beta.MultiplyNTT(key.a)
 	if err != nil {
 		return nil, err
@@ -230,86 +424,85 @@ restart:
 	v := valpha.Add(vbeta)
 	v = v.Mod2Q()
 	dv := v.DropBits().ModP()
-	indices := computeC(kappa, dv, hash[:])
-	v1, v2 := greedySc(indices, key.s1, key.s2)
-	normV := v1.Norm2() + v2.Norm2()
-	if M <= uint32(normV) {
-		return nil, fmt.Errorf("|v|^2 is larger than M")
-	}
-	if !sampler.SampleBerExp(M - uint32(normV)) {
-		goto restart
-	}
-	var z1, z2 *poly.PolyArray
-	b := entropy.Bit()
-	if b {
-		z1 = y1alpha.Sub(v1)
-		z2 = y2alpha.Sub(v2)
-		z1 = z1.Add(y1beta)
-		z2 = z2.Add(y2beta)
-	} else {
-		z1 = y1alpha.Add(v1)
-		z2 = y2alpha.Add(v2)
-		z1 = z1.Add(y1beta)
-		z2 = z2.Add(y2beta)
+	y1 := y1alpha.Add(y1beta)
+	y2 := y2alpha.Add(y2beta)
+	// The parts are no longer needed once they are summed.
+	for _, p := range []*poly.PolyArray{y1alpha, y2alpha, y1beta, y2beta, valpha, vbeta} {
+		p.Wipe()
 	}
-	prodZV := z1.InnerProduct(v1) + z2.InnerProduct(v2)
-	if !sampler.SampleBerCosh(prodZV) {
-		goto restart
-	}
-	y1 := v.Sub(z2).Mod2Q().DropBits()
-	v = v.DropBits()
-	z2 = v.Sub(y1).BoundByP()
-	if z1.MaxNorm() > int32(Binf) {
-		goto restart
-	}
-	y2 := z2.Mul2d()
-	if y2.MaxNorm() > int32(Binf) {
-		goto restart
-	}
-	if z1.Norm2()+y2.Norm2() > int32(Bl2) {
-		goto restart
-	}
-	return &BlissSignature{z1, z2, indices}, nil
+	return &commitment{y1, y2, v, dv, sampler, entropy}, nil
 }
 
 // The BLISS signature verification algorithm.
+// The result is true if the signature is valid, and false if it is rejected.
+// An error is only returned if the input is malformed, e.g. the signature is
+// nil or of a different BLISS version from the key, in which case the result
+// is false as well. So a verifier may simply test the boolean result.
 func (key *BlissPublicKey) Verify(msg []byte, sig *BlissSignature) (bool, error) {
-	if key.a.Param().Version != sig.z1.Param().Version {
-		return false, fmt.Errorf("Mismatched signature version")
-	}
-	z1, z2, indices := sig.z1, sig.z2, sig.c
-	param := key.a.Param()
-	if z1.MaxNorm() > int32(param.Binf) {
-		return false, fmt.Errorf("z1 max norm too large")
-	}
-	tz2 := z2.Mul2d()
-	if tz2.MaxNorm() > int32(param.Binf) {
-		return false, fmt.Errorf("z2 max norm too large")
-	}
-	if z1.Norm2()+tz2.Norm2() > int32(param.Bl2) {
-		return false, fmt.Errorf("t1,z2 L2 norm too large")
-	}
 	hash := sha3.Sum512(msg)
-	v, err := z1.MultiplyNTT(key.a)
+	return key.verify(hash[:], sig)
+}
+
+// The BLISS signature verification algorithm, given the message hash that is
+// fed into computeC.
+func (key *BlissPublicKey) verify(hash []byte, sig *BlissSignature) (bool, error) {
+	prepared, err := key.Prepare()
 	if err != nil {
 		return false, err
 	}
-	v.ScalarMul(2)
-	v.ScalarMul(int32(key.Param().OneQ2))
-	v = v.Mod2Q()
-	vdata := v.GetData()
-	for i := 0; i < len(indices); i++ {
-		qq := param.Q * param.OneQ2
-		vdata[indices[i]] = v.NumMod2Q(vdata[indices[i]] + int32(qq))
-	}
-	v = v.DropBits().Add(z2).ModP()
-	indicesp := computeC(param.Kappa, v, hash[:])
-	for i := 0; i < len(indices); i++ {
-		if indices[i] != indicesp[i] {
-			return false, fmt.Errorf("Indices mismatch!")
-		}
-	}
-	return true, nil
+	return prepared.verify(hash, sig)
+}
+
+// The checks of the verification algorithm, one of which a signature fails
+// when it is rejected.
+type VerifyFailure int
+
+const (
+	// The signature is valid.
+	VerifyOK VerifyFailure = iota
+	// The signature is of a different BLISS version from the public key.
+	VerifyVersionMismatch
+	// The max norm of z1 is larger than B_inf.
+	VerifyZ1MaxNorm
+	// The max norm of z2*2^d is larger than B_inf.
+	VerifyZ2MaxNorm
+	// The L2 norm of (z1,z2*2^d) is larger than B_l2.
+	VerifyL2Norm
+	// The recomputed challenge differs from the challenge c.
+	VerifyIndicesMismatch
+)
+
+// Get the description of the failed check.
+func (failure VerifyFailure) String() string {
+	switch failure {
+	case VerifyOK:
+		return "Signature valid"
+	case VerifyVersionMismatch:
+		return "Mismatched signature version"
+	case VerifyZ1MaxNorm:
+		return "z1 max norm too large"
+	case VerifyZ2MaxNorm:
+		return "z2 max norm too large"
+	case VerifyL2Norm:
+		return "t1,z2 L2 norm too large"
+	case VerifyIndicesMismatch:
+		return "Indices mismatch!"
+	}
+	return fmt.Sprintf("Unknown verify failure %d", int(failure))
+}
+
+// Convert the failed check to an error, for the callers that handle a
+// rejected signature as an error. The error is ErrVersionMismatch for
+// VerifyVersionMismatch, and wraps ErrInvalidSignature for the other failures.
+// VerifyOK gives nil.
+func (failure VerifyFailure) Err() error {
+	switch failure {
+	case VerifyOK:
+		return nil
+	case VerifyVersionMismatch:
+		return ErrVersionMismatch
+	}
+	return fmt.Errorf("%s: %w", failure.String(), ErrInvalidSignature)
 }
 
 // Get the BLISS parameter set from the signature.
@@ -334,7 +527,10 @@ func (sig *BlissSignature) Param() *params.BlissBParam {
 // Finally, the entire data is prefixed by a byte specifying the BLISS version.
 // The signature format is
 // [ Version | low bits and sign of z1 | challenge c | huffman(z1/2^d,z2) ]
-func (sig *BlissSignature) Serialize() []byte {
+// An error wrapping ErrInvalidSignature is returned if the signature has
+// coefficients or indices that the format cannot hold, which never happens to
+// a signature accepted by Verify.
+func (sig *BlissSignature) Serialize() ([]byte, error) {
 	cpacker := huffman.NewBitPacker()
 	zpacker := huffman.NewBitPacker()
 	n := sig.Param().N
@@ -345,17 +541,28 @@ func (sig *BlissSignature) Serialize() []byte {
 	code := sig.Param().Code
 	z1data := sig.z1.GetData()
 	z2data := sig.z2.GetData()
+	if len(sig.c) != int(kappa) {
+		return nil, fmt.Errorf("Invalid number of indices %d, expect %d: %w",
+			len(sig.c), kappa, ErrInvalidSignature)
+	}
 	ret := make([]byte, 1)
 	ret[0] = byte(version)
 	for i := 0; i < int(kappa); i++ {
-		cpacker.WriteBits(uint64(sig.c[i]), nbit)
+		if sig.c[i] >= n {
+			return nil, fmt.Errorf("Invalid index %d, expect < %d: %w", sig.c[i], n, ErrInvalidSignature)
+		}
+		if err := cpacker.WriteBits(uint64(sig.c[i]), nbit); err != nil {
+			return nil, err
+		}
 	}
 	for i := 0; i < int(n); i++ {
 		bits := Abs(z1data[i]) & 0xff
 		if z1data[i] < 0 {
 			bits |= 0x100
 		}
-		zpacker.WriteBits(uint64(bits), 9)
+		if err := zpacker.WriteBits(uint64(bits), 9); err != nil {
+			return nil, err
+		}
 	}
 	ret = append(ret, zpacker.Data()...)
 	ret = append(ret, cpacker.Data()...)
@@ -364,24 +571,52 @@ func (sig *BlissSignature) Serialize() []byte {
 		z1 := Abs(z1data[i]) >> 8
 		z2 := z2data[i]
 		index := int(z1)*(int(nz2)*2-1) + int(z2) + int(nz2) - 1
-		if index < 0 {
-			fmt.Printf("z1 = %d, z2 = %d, index = %d\n", z1, z2, index)
-			return []byte{}
+		if z2 <= -int32(nz2) || z2 >= int32(nz2) || index < 0 {
+			return nil, fmt.Errorf("Cannot encode z1 = %d, z2 = %d: %w",
+				z1data[i], z2, ErrInvalidSignature)
 		}
-		err := encoder.Update(index)
-		if err != nil {
-			return []byte{}
+		if err := encoder.Update(index); err != nil {
+			return nil, fmt.Errorf("Cannot encode z1 = %d, z2 = %d: %w",
+				z1data[i], z2, ErrInvalidSignature)
 		}
 	}
 	ret = append(ret, encoder.Digest()...)
-	return ret
+	return ret, nil
+}
+
+// Deserialize a BLISS signature from binary form, and require the data to be
+// the canonical encoding of the signature, i.e. exactly what Serialize gives
+// for the decoded signature. Trailing bytes, non-zero padding bits, leftover
+// Huffman bits and a negative zero in z1 are all rejected with an error
+// wrapping ErrMalformedEncoding. So every accepted data satisfies
+// Serialize(DeserializeBlissSignatureStrict(data)) == data, and a valid
+// signature has exactly one accepted encoding, which is required when
+// signatures are deduplicated or indexed by their bytes.
+func DeserializeBlissSignatureStrict(data []byte) (*BlissSignature, error) {
+	sig, err := DeserializeBlissSignature(data)
+	if err != nil {
+		return nil, err
+	}
+	enc, err := sig.Serialize()
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", err.Error(), ErrMalformedEncoding)
+	}
+	if !bytes.Equal(enc, data) {
+		return nil, fmt.Errorf("Non-canonical signature encoding: %w", ErrMalformedEncoding)
+	}
+	return sig, nil
 }
 
 // Deserialize a BLISS signature from binary form.
+// An error is returned if the data is too short for the version it specifies.
+// Non-canonical encodings are accepted, see DeserializeBlissSignatureStrict.
 func DeserializeBlissSignature(data []byte) (*BlissSignature, error) {
+	if len(data) == 0 {
+		return nil, fmt.Errorf("Empty signature data: %w", ErrMalformedEncoding)
+	}
 	z1, err := poly.New(int(data[0]))
 	if err != nil {
-		return nil, fmt.Errorf("Error in generating new polyarray: %s", err.Error())
+		return nil, fmt.Errorf("Unknown BLISS version %d: %w", data[0], ErrMalformedEncoding)
 	}
 	param := z1.Param()
 	z2, err := poly.NewPolyArray(param)
@@ -400,17 +635,24 @@ func DeserializeBlissSignature(data []byte) (*BlissSignature, error) {
 	cdata := make([]uint32, kappa)
 
 	csize := (nbit*kappa + 7) / 8
-	lowsize := 9 * n / 8
+	lowsize := (9*n + 7) / 8
+	if len(data) < int(1+lowsize+csize) {
+		return nil, fmt.Errorf("Signature data too short: %d bytes: %w", len(data), ErrMalformedEncoding)
+	}
 	lowsrc := data[1 : 1+lowsize]
 	csrc := data[1+lowsize : 1+lowsize+csize]
 	z1z2 := data[1+lowsize+csize:]
 
-	decoder := huffman.NewHuffmanDecoder(code, z1z2)
+	decoder, err := huffman.NewHuffmanDecoder(code, z1z2)
+	if err != nil {
+		return nil, fmt.Errorf("Error in decoding huffman: %s: %w", err.Error(), ErrMalformedEncoding)
+	}
 	zunpacker := huffman.NewBitUnpacker(lowsrc, 9*n)
 	for i := 0; i < int(n); i++ {
 		bits, err := zunpacker.ReadBits(9)
 		if err != nil {
-			return nil, fmt.Errorf("Error in unpacking lower part of z1: %s", err.Error())
+			return nil, fmt.Errorf("Error in unpacking lower part of z1: %s: %w",
+				err.Error(), ErrMalformedEncoding)
 		}
 		sign := int32(1)
 		if bits&0x100 > 0 {
@@ -419,10 +661,10 @@ func DeserializeBlissSignature(data []byte) (*BlissSignature, error) {
 		z1low := int32(bits & 0xff)
 		index, err := decoder.Next()
 		if err != nil {
-			return nil, fmt.Errorf("Error in decoding huffman: %s", err.Error())
+			return nil, fmt.Errorf("Error in decoding huffman: %s: %w", err.Error(), ErrMalformedEncoding)
 		}
 		if index < 0 {
-			return nil, fmt.Errorf("Invalid index %d", index)
+			return nil, fmt.Errorf("Invalid index %d: %w", index, ErrMalformedEncoding)
 		}
 		z1high := index / (int(nz2)*2 - 1)
 		z2 := int32(index%(int(nz2)*2-1) - int(nz2) + 1)
@@ -435,7 +677,10 @@ func DeserializeBlissSignature(data []byte) (*BlissSignature, error) {
 	for i := 0; i < int(kappa); i++ {
 		bits, err := cunpacker.ReadBits(nbit)
 		if err != nil {
-			return nil, fmt.Errorf("Error in unpacking c: %s", err.Error())
+			return nil, fmt.Errorf("Error in unpacking c: %s: %w", err.Error(), ErrMalformedEncoding)
+		}
+		if bits >= uint64(n) {
+			return nil, fmt.Errorf("Invalid index %d, expect < %d: %w", bits, n, ErrMalformedEncoding)
 		}
 		cdata[i] = uint32(bits)
 	}
@@ -1,6 +1,7 @@
 package bliss
 
 import (
+	"errors"
 	"fmt"
 	_ "io/ioutil"
 	"params"
@@ -52,7 +53,7 @@ func TestSignVerify(t *testing.T) {
 			}
 			fmt.Printf("\n")
 		*/
-		_, err = pub.Verify(msg, sig)
+		err = verified(pub.Verify(msg, sig))
 		if err != nil {
 			t.Errorf("Failed to verify signature for version %d: %s", i, err.Error())
 		}
@@ -100,7 +101,7 @@ func TestSignVerifyAgainstChannel(t *testing.T) {
 			}
 			fmt.Printf("\n")
 		*/
-		_, err = pub.Verify(msg, sig)
+		err = verified(pub.Verify(msg, sig))
 		if err != nil {
 			t.Errorf("Failed to verify signature for version %d: %s", i, err.Error())
 		}
@@ -128,10 +129,13 @@ func TestSignatureEncodeDecode(t *testing.T) {
 			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
 		}
 
-		enc := sig.Encode()
-		tmp, err := DecodeBlissSignature(enc)
+		enc, err := sig.Serialize()
 		if err != nil {
-			t.Errorf("Error in decoding signature : %s", err.Error())
+			t.Errorf("Error in serializing signature: %s", err.Error())
+		}
+		tmp, err := DeserializeBlissSignature(enc)
+		if err != nil {
+			t.Errorf("Error in deserializing signature: %s", err.Error())
 		}
 		if !reflect.DeepEqual(sig, tmp) {
 			t.Errorf("Different signature decoded for version %d!\nOriginal:\n%s\ngot:\n%s\n",
@@ -287,7 +291,11 @@ func TestSignatureSerializeDeserialize(t *testing.T) {
 			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
 		}
 
-		enc := sig.Serialize()
+		enc, err := sig.Serialize()
+		if err != nil {
+			t.Errorf("Failed to encode signature for version %d: %s", i, err.Error())
+			continue
+		}
 		fmt.Printf("Size of signature for BLISS-%d: %d bytes (%d bits)\n", i, len(enc), len(enc)*8)
 		if len(enc) == 0 {
 			t.Errorf("Failed to encode signature for version %d", i)
@@ -303,3 +311,80 @@ func TestSignatureSerializeDeserialize(t *testing.T) {
 		}
 	}
 }
+
+func TestSignatureStrictEncoding(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+		}
+
+		msg := []byte("Hello world")
+		sig, err := key.Sign(msg, entropy)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		enc, err := sig.Serialize()
+		if err != nil {
+			t.Errorf("Failed to encode signature for version %d: %s", i, err.Error())
+			continue
+		}
+		tmp, err := DeserializeBlissSignatureStrict(enc)
+		if err != nil {
+			t.Errorf("Error in decoding canonical signature: %s", err.Error())
+		} else if !reflect.DeepEqual(sig, tmp) {
+			t.Errorf("Different signature decoded for version %d", i)
+		}
+
+		param := key.Param()
+		offset := 1 + int(9*param.N+7)/8 + int(param.Nbits*param.Kappa+7)/8
+		size := int(enc[offset])*256 + int(enc[offset+1])
+		malformed := map[string][]byte{}
+		malformed["trailing byte"] = append(append([]byte{}, enc...), 0)
+		leftover := append(append([]byte{}, enc...), 0)
+		leftover[offset] = byte((size + 8) / 256)
+		leftover[offset+1] = byte((size + 8) % 256)
+		malformed["leftover Huffman bits"] = leftover
+		if size%8 != 0 {
+			padding := append([]byte{}, enc...)
+			padding[len(padding)-1] |= 1
+			malformed["padding bit"] = padding
+		}
+		zero := &BlissSignature{sig.z1.ScalarTimes(1), sig.z2, sig.c}
+		zero.z1.GetData()[0] = 0
+		negzero, err := zero.Serialize()
+		if err != nil {
+			t.Errorf("Failed to encode signature for version %d: %s", i, err.Error())
+			continue
+		}
+		negzero[1] |= 0x80
+		malformed["negative zero"] = negzero
+
+		for name, data := range malformed {
+			if _, err := DeserializeBlissSignature(data); err != nil {
+				t.Errorf("Lenient decoding rejects %s: %s", name, err.Error())
+			}
+			if _, err := DeserializeBlissSignatureStrict(data); !errors.Is(err, ErrMalformedEncoding) {
+				t.Errorf("Strict decoding accepts %s for version %d", name, i)
+			}
+		}
+
+		// Repeated indices are malformed.
+		c := append([]uint32{}, sig.c...)
+		c[1] = c[0]
+		ok, err := key.PublicKey().Verify(msg, &BlissSignature{sig.z1, sig.z2, c})
+		if ok || !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("Expect ErrInvalidSignature for repeated indices, got %v, %v", ok, err)
+		}
+	}
+}
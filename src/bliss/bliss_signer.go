@@ -0,0 +1,165 @@
+package bliss
+
+import (
+	"golang.org/x/crypto/sha3"
+	"io"
+	"params"
+	"sampler"
+	"sync"
+)
+
+// A Signer is a long-lived signing object for a fixed private key.
+// The signing methods of BlissPrivateKey look up the parameter set, build the
+// sampler tables and allocate the Gaussian polynomials on every call. A Signer
+// does all of this once, and keeps a pool of scratch polynomials, so repeated
+// signing only does the per-signature work.
+// A Signer is safe for concurrent use by multiple goroutines, provided that
+// each concurrent call is given its own entropy.
+type Signer struct {
+	key      *BlissPrivateKey
+	param    *params.BlissBParam
+	sampler  *sampler.Sampler
+	nonceKey [64]byte
+	scratch  sync.Pool
+	// mu is held for reading while signing, and for writing by Destroy.
+	mu        sync.RWMutex
+	destroyed bool
+}
+
+// Create a signer for the given private key.
+func NewSigner(key *BlissPrivateKey) (*Signer, error) {
+	param := key.Param()
+	s, err := sampler.New(param.Version, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Make sure the scratch polynomials can be allocated, so that the pool
+	// never fails later.
+	if _, err := newSignScratch(param); err != nil {
+		return nil, err
+	}
+	nonceKey, err := key.nonceKey()
+	if err != nil {
+		return nil, err
+	}
+	signer := &Signer{key: key, param: param, sampler: s, nonceKey: nonceKey}
+	wipeBytes(nonceKey[:])
+	signer.scratch.New = func() interface{} {
+		scratch, _ := newSignScratch(param)
+		return scratch
+	}
+	return signer, nil
+}
+
+// Retrieve the BLISS public key of the signer.
+func (signer *Signer) PublicKey() *BlissPublicKey {
+	return signer.key.PublicKey()
+}
+
+// Retrieve the BLISS parameter set of the signer.
+func (signer *Signer) Param() *params.BlissBParam {
+	return signer.param
+}
+
+// Wipe the nonce key and the pooled scratch polynomials of the signer, and
+// wait for the signing in progress to finish first. The signing methods fail
+// with ErrKeyDestroyed afterwards. The private key of the signer is not
+// destroyed. Destroying a signer twice is harmless.
+func (signer *Signer) Destroy() {
+	signer.mu.Lock()
+	defer signer.mu.Unlock()
+	signer.destroyed = true
+	wipeBytes(signer.nonceKey[:])
+	signer.scratch.New = nil
+	for {
+		scratch, ok := signer.scratch.Get().(*signScratch)
+		if !ok {
+			break
+		}
+		scratch.wipe()
+	}
+}
+
+// Hold the signer for reading while signing, and fail if it is destroyed.
+// The caller must call signer.mu.RUnlock when the signing is done.
+func (signer *Signer) acquire() error {
+	signer.mu.RLock()
+	if signer.destroyed {
+		signer.mu.RUnlock()
+		return ErrKeyDestroyed
+	}
+	return nil
+}
+
+// Sign the message hash fed into computeC with a sampler drawing from entropy
+// and scratch polynomials taken from the pool. The scratch polynomials are
+// wiped before they are put back.
+func (signer *Signer) sign(hash []byte, entropy *sampler.Entropy, sideChannel bool) (*BlissSignature, error) {
+	scratch := signer.scratch.Get().(*signScratch)
+	defer signer.scratch.Put(scratch)
+	defer scratch.wipe()
+	s := signer.sampler.WithEntropy(entropy)
+	if sideChannel {
+		return signer.key.signAgainstSideChannelWith(s, scratch, hash, entropy)
+	}
+	return signer.key.signWith(s, scratch, hash, entropy)
+}
+
+// The BLISS signature generation algorithm. The signature is identical to
+// that of BlissPrivateKey.Sign given the same message and entropy.
+func (signer *Signer) Sign(msg []byte, entropy *sampler.Entropy) (*BlissSignature, error) {
+	if err := signer.acquire(); err != nil {
+		return nil, err
+	}
+	defer signer.mu.RUnlock()
+	hash := sha3.Sum512(msg)
+	return signer.sign(hash[:], entropy, false)
+}
+
+// The BLISS signature generation algorithm, which is supposed to be secure
+// against side-channel attacks.
+func (signer *Signer) SignAgainstSideChannel(msg []byte, entropy *sampler.Entropy) (*BlissSignature, error) {
+	if err := signer.acquire(); err != nil {
+		return nil, err
+	}
+	defer signer.mu.RUnlock()
+	hash := sha3.Sum512(msg)
+	return signer.sign(hash[:], entropy, true)
+}
+
+// The deterministic BLISS signature generation algorithm, as by
+// BlissPrivateKey.SignDeterministic.
+func (signer *Signer) SignDeterministic(msg []byte) (*BlissSignature, error) {
+	if err := signer.acquire(); err != nil {
+		return nil, err
+	}
+	defer signer.mu.RUnlock()
+	hash := sha3.Sum512(msg)
+	entropy, err := nonceEntropy(&signer.nonceKey, hash[:], nil)
+	if err != nil {
+		return nil, err
+	}
+	defer entropy.Destroy()
+	return signer.sign(hash[:], entropy, false)
+}
+
+// The hedged BLISS signature generation algorithm, as by
+// BlissPrivateKey.SignHedged.
+func (signer *Signer) SignHedged(msg []byte, rand io.Reader) (*BlissSignature, error) {
+	if err := signer.acquire(); err != nil {
+		return nil, err
+	}
+	defer signer.mu.RUnlock()
+	hash := sha3.Sum512(msg)
+	rnd, err := readHedge(rand)
+	if err != nil {
+		return nil, err
+	}
+	defer wipeBytes(rnd)
+	entropy, err := nonceEntropy(&signer.nonceKey, hash[:], rnd)
+	if err != nil {
+		return nil, err
+	}
+	defer entropy.Destroy()
+	return signer.sign(hash[:], entropy, false)
+}
@@ -0,0 +1,149 @@
+package bliss
+
+import (
+	"params"
+	"reflect"
+	"sampler"
+	"sync"
+	"testing"
+)
+
+func TestSigner(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+		}
+		signer, err := NewSigner(key)
+		if err != nil {
+			t.Errorf("Error in creating signer: %s", err.Error())
+			continue
+		}
+
+		msg := []byte("Hello world")
+		entropy1, _ := sampler.NewEntropy(seed)
+		entropy2, _ := sampler.NewEntropy(seed)
+		sig1, err := key.Sign(msg, entropy1)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		sig2, err := signer.Sign(msg, entropy2)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(sig1, sig2) {
+			t.Errorf("Different signature from signer for version %d", i)
+		}
+
+		entropy1, _ = sampler.NewEntropy(seed)
+		entropy2, _ = sampler.NewEntropy(seed)
+		sig1, err = key.SignAgainstSideChannel(msg, entropy1)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		sig2, err = signer.SignAgainstSideChannel(msg, entropy2)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(sig1, sig2) {
+			t.Errorf("Different side-channel signature from signer for version %d", i)
+		}
+
+		sig1, err = key.SignDeterministic(msg)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		sig2, err = signer.SignDeterministic(msg)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(sig1, sig2) {
+			t.Errorf("Different deterministic signature from signer for version %d", i)
+		}
+	}
+}
+
+func TestSignerConcurrent(t *testing.T) {
+	seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+	entropy, err := sampler.NewEntropy(seed)
+	if err != nil {
+		t.Errorf("Error in initializing entropy: %s", err.Error())
+	}
+	key, err := GeneratePrivateKey(params.BLISS_B_1, entropy)
+	if err != nil {
+		t.Errorf("Error in generating private key: %s", err.Error())
+	}
+	signer, err := NewSigner(key)
+	if err != nil {
+		t.Errorf("Error in creating signer: %s", err.Error())
+	}
+	pub := signer.PublicKey()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 4; j++ {
+				msg := []byte{byte(i), byte(j)}
+				sig, err := signer.SignHedged(msg, nil)
+				if err != nil {
+					t.Errorf("Failed to generate signature: %s", err.Error())
+					return
+				}
+				err = verified(pub.Verify(msg, sig))
+				if err != nil {
+					t.Errorf("Failed to verify signature: %s", err.Error())
+				}
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func benchSigner(b *testing.B, version int) {
+	seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+	for i := 0; i < len(seed); i++ {
+		seed[i] = uint8(i % 8)
+	}
+	entropy, err := sampler.NewEntropy(seed)
+	if err != nil {
+		b.Errorf("Error in initializing entropy: %s", err.Error())
+	}
+
+	key, err := GeneratePrivateKey(version, entropy)
+	if err != nil {
+		b.Errorf("Error in generating private key: %s", err.Error())
+	}
+	signer, err := NewSigner(key)
+	if err != nil {
+		b.Errorf("Error in creating signer: %s", err.Error())
+	}
+
+	msg := []byte("Hello world")
+	for i := 0; i < b.N; i++ {
+		signer.Sign(msg, entropy)
+	}
+}
+
+func BenchmarkSignerBliss0(b *testing.B) {
+	benchSigner(b, params.BLISS_B_0)
+}
+
+func BenchmarkSignerBliss4(b *testing.B) {
+	benchSigner(b, params.BLISS_B_4)
+}
@@ -0,0 +1,137 @@
+package bliss
+
+import (
+	"fmt"
+	"sync"
+)
+
+// The outcome of a signing attempt: either accepted, or the branch of the
+// rejection sampling that caused the restart.
+type Rejection int
+
+const (
+	// The attempt produced the signature.
+	Accepted Rejection = iota
+	// Rejected by the Bernoulli sampling of exp(-|v|^2/(2*sigma^2)).
+	RejectBerExp
+	// Rejected by the Bernoulli sampling of 1/cosh(<z,v>/sigma^2).
+	RejectBerCosh
+	// The max norm of z1 is larger than B_inf.
+	RejectZ1MaxNorm
+	// The max norm of z2*2^d is larger than B_inf.
+	RejectZ2MaxNorm
+	// The L2 norm of (z1,z2*2^d) is larger than B_l2.
+	RejectL2Norm
+	numRejections
+)
+
+// Get the description of the outcome.
+func (r Rejection) String() string {
+	switch r {
+	case Accepted:
+		return "accepted"
+	case RejectBerExp:
+		return "BerExp rejection"
+	case RejectBerCosh:
+		return "BerCosh rejection"
+	case RejectZ1MaxNorm:
+		return "z1 max norm too large"
+	case RejectZ2MaxNorm:
+		return "z2 max norm too large"
+	case RejectL2Norm:
+		return "z1,z2 L2 norm too large"
+	}
+	return fmt.Sprintf("Unknown rejection %d", int(r))
+}
+
+// A SignStatsSink receives the outcome of every signing attempt, together
+// with the squared norm |v|^2 = |s1*c|^2 + |s2*c|^2 of the attempt.
+type SignStatsSink interface {
+	RecordAttempt(normV int32, outcome Rejection)
+}
+
+// Set the sink receiving the outcome of every signing attempt with the key,
+// by any of its signing methods, including Signer, SignParallel and
+// CommitmentPool. The Stats of SignOptions takes precedence for SignContext.
+// A nil sink stops the recording. The sink must be set before the key is
+// used for signing, not concurrently with it.
+func (key *BlissPrivateKey) SetSignStats(stats SignStatsSink) {
+	key.stats = stats
+}
+
+// Record the outcome of an attempt into a sink that may be nil.
+func recordAttempt(stats SignStatsSink, normV int32, outcome Rejection) {
+	if stats != nil {
+		stats.RecordAttempt(normV, outcome)
+	}
+}
+
+// SignStats is a SignStatsSink that counts the attempts by outcome, and
+// keeps track of |v|^2. It is safe for concurrent use by multiple goroutines.
+type SignStats struct {
+	mu       sync.Mutex
+	outcomes [numRejections]uint64
+	attempts uint64
+	sumNormV uint64
+	maxNormV int32
+}
+
+// Record the outcome of a signing attempt.
+func (stats *SignStats) RecordAttempt(normV int32, outcome Rejection) {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	if outcome >= 0 && outcome < numRejections {
+		stats.outcomes[outcome]++
+	}
+	stats.attempts++
+	stats.sumNormV += uint64(normV)
+	if normV > stats.maxNormV {
+		stats.maxNormV = normV
+	}
+}
+
+// Return the total number of attempts.
+func (stats *SignStats) Attempts() uint64 {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	return stats.attempts
+}
+
+// Return the number of attempts with the given outcome. The number of
+// signatures produced is Count(Accepted).
+func (stats *SignStats) Count(outcome Rejection) uint64 {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	if outcome < 0 || outcome >= numRejections {
+		return 0
+	}
+	return stats.outcomes[outcome]
+}
+
+// Return the observed repetition rate, i.e. the number of attempts per
+// signature, which is expected to approach BlissBParam.RR.
+func (stats *SignStats) RepetitionRate() float64 {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	if stats.outcomes[Accepted] == 0 {
+		return 0
+	}
+	return float64(stats.attempts) / float64(stats.outcomes[Accepted])
+}
+
+// Return the mean of |v|^2 over all attempts.
+func (stats *SignStats) MeanNormV() float64 {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	if stats.attempts == 0 {
+		return 0
+	}
+	return float64(stats.sumNormV) / float64(stats.attempts)
+}
+
+// Return the largest |v|^2 over all attempts.
+func (stats *SignStats) MaxNormV() int32 {
+	stats.mu.Lock()
+	defer stats.mu.Unlock()
+	return stats.maxNormV
+}
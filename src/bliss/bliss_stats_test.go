@@ -0,0 +1,150 @@
+package bliss
+
+import (
+	"context"
+	"math"
+	"sampler"
+	"testing"
+)
+
+// Sign count messages by the given version with stats attached, and check
+// that the observed repetition rate is within tolerance (relative) of
+// BlissBParam.RR of the version.
+func checkRepetitionRate(t testing.TB, version int, count int, tolerance float64) *SignStats {
+	seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+	for i := 0; i < len(seed); i++ {
+		seed[i] = uint8(i % 8)
+	}
+	entropy, err := sampler.NewEntropy(seed)
+	if err != nil {
+		t.Errorf("Error in initializing entropy: %s", err.Error())
+		return nil
+	}
+	key, err := GeneratePrivateKey(version, entropy)
+	if err != nil {
+		t.Errorf("Error in generating private key: %s", err.Error())
+		return nil
+	}
+	stats := &SignStats{}
+	opts := &SignOptions{Entropy: entropy, Stats: stats}
+	for j := 0; j < count; j++ {
+		_, err := key.SignContext(context.Background(), []byte{byte(j), byte(j >> 8)}, opts)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", version, err.Error())
+			return stats
+		}
+	}
+	expect := key.Param().RR
+	rate := stats.RepetitionRate()
+	if math.Abs(rate-expect) > tolerance*expect {
+		t.Errorf("Repetition rate of version %d is %f, expect %f", version, rate, expect)
+	}
+	return stats
+}
+
+func TestSignStats(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		stats := checkRepetitionRate(t, i, 1000, 0.1)
+		if stats == nil {
+			continue
+		}
+		if stats.Count(Accepted) != 1000 {
+			t.Errorf("Expect 1000 signatures, got %d", stats.Count(Accepted))
+		}
+		total := uint64(0)
+		for r := Accepted; r <= RejectL2Norm; r++ {
+			total += stats.Count(r)
+		}
+		if total != stats.Attempts() {
+			t.Errorf("Outcomes sum up to %d, but %d attempts", total, stats.Attempts())
+		}
+		if stats.MaxNormV() <= 0 || stats.MeanNormV() > float64(stats.MaxNormV()) {
+			t.Errorf("Invalid norm of v: mean %f, max %d", stats.MeanNormV(), stats.MaxNormV())
+		}
+	}
+}
+
+func TestSetSignStats(t *testing.T) {
+	seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+	entropy, err := sampler.NewEntropy(seed)
+	if err != nil {
+		t.Errorf("Error in initializing entropy: %s", err.Error())
+	}
+	key, err := GeneratePrivateKey(1, entropy)
+	if err != nil {
+		t.Errorf("Error in generating private key: %s", err.Error())
+		return
+	}
+	stats := &SignStats{}
+	key.SetSignStats(stats)
+	msg := []byte("Hello world")
+	signer, err := NewSigner(key)
+	if err != nil {
+		t.Errorf("Error in creating signer: %s", err.Error())
+		return
+	}
+	signs := []func() (*BlissSignature, error){
+		func() (*BlissSignature, error) { return key.Sign(msg, entropy) },
+		func() (*BlissSignature, error) { return key.SignAgainstSideChannel(msg, entropy) },
+		func() (*BlissSignature, error) { return key.SignDeterministic(msg) },
+		func() (*BlissSignature, error) { return key.SignParallel(msg, entropy, 2) },
+		func() (*BlissSignature, error) { return signer.Sign(msg, entropy) },
+		func() (*BlissSignature, error) { return key.SignContext(context.Background(), msg, nil) },
+	}
+	for j, sign := range signs {
+		if _, err := sign(); err != nil {
+			t.Errorf("Failed to generate signature %d: %s", j, err.Error())
+		}
+		if stats.Count(Accepted) != uint64(j+1) {
+			t.Errorf("Signature %d not recorded: %d accepted", j, stats.Count(Accepted))
+		}
+	}
+
+	// The sink of the options takes precedence.
+	other := &SignStats{}
+	if _, err := key.SignContext(context.Background(), msg, &SignOptions{Stats: other}); err != nil {
+		t.Errorf("Failed to generate signature: %s", err.Error())
+	}
+	if other.Count(Accepted) != 1 || stats.Count(Accepted) != uint64(len(signs)) {
+		t.Errorf("Signature recorded in the wrong sink")
+	}
+	key.SetSignStats(nil)
+	if _, err := key.Sign(msg, entropy); err != nil {
+		t.Errorf("Failed to generate signature: %s", err.Error())
+	}
+	if stats.Count(Accepted) != uint64(len(signs)) {
+		t.Errorf("Signature recorded after the sink is removed")
+	}
+}
+
+func benchRepetitionRate(b *testing.B, version int) {
+	// Too few signatures tell nothing about the rate.
+	tolerance := math.Inf(1)
+	if b.N >= 1000 {
+		tolerance = 0.1
+	}
+	stats := checkRepetitionRate(b, version, b.N, tolerance)
+	if stats != nil {
+		b.ReportMetric(stats.RepetitionRate(), "attempts/sig")
+	}
+}
+
+func BenchmarkRepetitionRate0(b *testing.B) {
+	benchRepetitionRate(b, 0)
+}
+
+func BenchmarkRepetitionRate1(b *testing.B) {
+	benchRepetitionRate(b, 1)
+}
+
+func BenchmarkRepetitionRate2(b *testing.B) {
+	benchRepetitionRate(b, 2)
+}
+
+func BenchmarkRepetitionRate3(b *testing.B) {
+	benchRepetitionRate(b, 3)
+}
+
+func BenchmarkRepetitionRate4(b *testing.B) {
+	benchRepetitionRate(b, 4)
+}
@@ -0,0 +1,70 @@
+package bliss
+
+import (
+	"golang.org/x/crypto/sha3"
+	"hash"
+	"sampler"
+)
+
+// A StreamSigner signs a message that is written into it piece by piece, so
+// the message never has to be held in memory at once.
+// Sign only depends on the SHA3-512 digest of the message, so the signatures
+// are identical to those of Sign given the same message and entropy.
+type StreamSigner struct {
+	key *BlissPrivateKey
+	h   hash.Hash
+}
+
+// A StreamVerifier verifies a signature of a message that is written into it
+// piece by piece. It accepts exactly the signatures accepted by Verify.
+type StreamVerifier struct {
+	key *BlissPublicKey
+	h   hash.Hash
+}
+
+// Create a stream signer for the given private key, with an empty message.
+func NewStreamSigner(key *BlissPrivateKey) *StreamSigner {
+	return &StreamSigner{key, sha3.New512()}
+}
+
+// Append p to the message to be signed. It never returns an error.
+func (signer *StreamSigner) Write(p []byte) (int, error) {
+	return signer.h.Write(p)
+}
+
+// Reset the message to be signed to empty.
+func (signer *StreamSigner) Reset() {
+	signer.h.Reset()
+}
+
+// Sign the message written so far. The message is left unchanged, so more
+// data can still be written afterwards.
+func (signer *StreamSigner) Finish(entropy *sampler.Entropy) (*BlissSignature, error) {
+	return signer.key.sign(signer.h.Sum(nil), entropy)
+}
+
+// Sign the message written so far by the algorithm secure against
+// side-channel attacks.
+func (signer *StreamSigner) FinishAgainstSideChannel(entropy *sampler.Entropy) (*BlissSignature, error) {
+	return signer.key.signAgainstSideChannel(signer.h.Sum(nil), entropy)
+}
+
+// Create a stream verifier for the given public key, with an empty message.
+func NewStreamVerifier(key *BlissPublicKey) *StreamVerifier {
+	return &StreamVerifier{key, sha3.New512()}
+}
+
+// Append p to the message to be verified. It never returns an error.
+func (verifier *StreamVerifier) Write(p []byte) (int, error) {
+	return verifier.h.Write(p)
+}
+
+// Reset the message to be verified to empty.
+func (verifier *StreamVerifier) Reset() {
+	verifier.h.Reset()
+}
+
+// Verify the signature of the message written so far.
+func (verifier *StreamVerifier) Verify(sig *BlissSignature) (bool, error) {
+	return verifier.key.verify(verifier.h.Sum(nil), sig)
+}
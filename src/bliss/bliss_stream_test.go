@@ -0,0 +1,74 @@
+package bliss
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"sampler"
+	"testing"
+)
+
+func TestStreamSignVerify(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+		}
+
+		msg := bytes.Repeat([]byte("Hello world"), 1000)
+		entropy1, _ := sampler.NewEntropy(seed)
+		entropy2, _ := sampler.NewEntropy(seed)
+		sig, err := key.Sign(msg, entropy1)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+
+		signer := NewStreamSigner(key)
+		_, err = io.Copy(signer, bytes.NewReader(msg))
+		if err != nil {
+			t.Errorf("Failed to write message for version %d: %s", i, err.Error())
+		}
+		ssig, err := signer.Finish(entropy2)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+		if !reflect.DeepEqual(sig, ssig) {
+			t.Errorf("Different signature from stream signer for version %d", i)
+		}
+
+		verifier := NewStreamVerifier(key.PublicKey())
+		for j := 0; j < len(msg); j += 777 {
+			end := j + 777
+			if end > len(msg) {
+				end = len(msg)
+			}
+			verifier.Write(msg[j:end])
+		}
+		err = verified(verifier.Verify(sig))
+		if err != nil {
+			t.Errorf("Failed to verify signature for version %d: %s", i, err.Error())
+		}
+		verifier.Write([]byte("!"))
+		err = verified(verifier.Verify(sig))
+		if err == nil {
+			t.Errorf("Verified signature of wrong message for version %d", i)
+		}
+		verifier.Reset()
+		verifier.Write(msg)
+		err = verified(verifier.Verify(sig))
+		if err != nil {
+			t.Errorf("Failed to verify signature after reset for version %d: %s", i, err.Error())
+		}
+	}
+}
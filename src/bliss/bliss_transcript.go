@@ -0,0 +1,103 @@
+//go:build bliss_transcript
+// +build bliss_transcript
+
+package bliss
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+)
+
+// WARNING: the transcript contains y1, y2 and (v1,v2) = (s1,s2)*c, from which
+// the private key is easily recovered. This file is only compiled with the
+// build tag bliss_transcript, and must never be used in production.
+
+// One line of the transcript, recording a single signing attempt.
+// Z1 and Z2 are only present if the attempt is accepted, and Z2 is the
+// compressed z2 of the signature.
+type TranscriptEntry struct {
+	Version  int      `json:"version"`
+	Run      uint64   `json:"run"`
+	Attempt  uint64   `json:"attempt"`
+	Y1       []int32  `json:"y1"`
+	Y2       []int32  `json:"y2"`
+	Indices  []uint32 `json:"indices"`
+	Signs    []int8   `json:"signs"`
+	V1       []int32  `json:"v1"`
+	V2       []int32  `json:"v2"`
+	NormV    int32    `json:"normV"`
+	Bit      bool     `json:"b"`
+	Outcome  string   `json:"outcome"`
+	Accepted bool     `json:"accepted"`
+	Z1       []int32  `json:"z1,omitempty"`
+	Z2       []int32  `json:"z2,omitempty"`
+}
+
+// The destination of the transcript of a key. Run counts the accepted
+// signatures, and attempt counts the attempts within the current run.
+type transcriptWriter struct {
+	sync.Mutex
+	enc     *json.Encoder
+	run     uint64
+	attempt uint64
+}
+
+var transcripts = struct {
+	sync.RWMutex
+	writers map[*BlissPrivateKey]*transcriptWriter
+}{writers: make(map[*BlissPrivateKey]*transcriptWriter)}
+
+// Record every signing attempt made with this key to w, one JSON object
+// (TranscriptEntry) per line. Pass a nil writer to stop the recording and
+// release the key. Attempts made concurrently are written in some serial
+// order, so the run numbers only make sense for sequential signing.
+func (key *BlissPrivateKey) SetTranscript(w io.Writer) {
+	transcripts.Lock()
+	defer transcripts.Unlock()
+	if w == nil {
+		delete(transcripts.writers, key)
+		return
+	}
+	transcripts.writers[key] = &transcriptWriter{enc: json.NewEncoder(w)}
+}
+
+// Write the transcript of a signing attempt, if the key has a transcript.
+// Write errors are dropped, since they must not affect the signing.
+func (key *BlissPrivateKey) traceAttempt(a *attempt, sig *BlissSignature) {
+	transcripts.RLock()
+	tw := transcripts.writers[key]
+	transcripts.RUnlock()
+	if tw == nil {
+		return
+	}
+	signs := make([]int8, len(a.indices))
+	greedyScSigns(a.indices, key.s1, key.s2, signs)
+	entry := TranscriptEntry{
+		Version:  key.Param().Version,
+		Y1:       a.c.y1.GetData(),
+		Y2:       a.c.y2.GetData(),
+		Indices:  a.indices,
+		Signs:    signs,
+		V1:       a.v1.GetData(),
+		V2:       a.v2.GetData(),
+		NormV:    a.normV,
+		Bit:      a.b,
+		Outcome:  a.outcome.String(),
+		Accepted: sig != nil,
+	}
+	if sig != nil {
+		entry.Z1 = sig.z1.GetData()
+		entry.Z2 = sig.z2.GetData()
+	}
+	tw.Lock()
+	defer tw.Unlock()
+	entry.Run = tw.run
+	entry.Attempt = tw.attempt
+	tw.attempt++
+	if sig != nil {
+		tw.run++
+		tw.attempt = 0
+	}
+	tw.enc.Encode(&entry)
+}
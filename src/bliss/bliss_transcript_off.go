@@ -0,0 +1,8 @@
+//go:build !bliss_transcript
+// +build !bliss_transcript
+
+package bliss
+
+// The transcript of the signing attempts is only available in the builds
+// with the tag bliss_transcript, see bliss_transcript.go.
+func (key *BlissPrivateKey) traceAttempt(a *attempt, sig *BlissSignature) {}
@@ -0,0 +1,95 @@
+//go:build bliss_transcript
+// +build bliss_transcript
+
+package bliss
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"sampler"
+	"testing"
+)
+
+func TestSignTranscript(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+		}
+
+		var buf bytes.Buffer
+		key.SetTranscript(&buf)
+		var sigs []*BlissSignature
+		for j := 0; j < 4; j++ {
+			sig, err := key.Sign([]byte{byte(j)}, entropy)
+			if err != nil {
+				t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			}
+			sigs = append(sigs, sig)
+		}
+		key.SetTranscript(nil)
+		key.Sign([]byte("Not recorded"), entropy)
+
+		scanner := bufio.NewScanner(&buf)
+		scanner.Buffer(nil, 1<<20)
+		accepted := 0
+		for scanner.Scan() {
+			var entry TranscriptEntry
+			if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+				t.Errorf("Invalid transcript line: %s", err.Error())
+				continue
+			}
+			if entry.Version != i || int(entry.Run) != accepted {
+				t.Errorf("Wrong version %d or run %d", entry.Version, entry.Run)
+			}
+			if len(entry.Signs) != len(entry.Indices) {
+				t.Errorf("Got %d signs for %d indices", len(entry.Signs), len(entry.Indices))
+			}
+			normV := int32(0)
+			for j := range entry.V1 {
+				normV += entry.V1[j]*entry.V1[j] + entry.V2[j]*entry.V2[j]
+			}
+			if normV != entry.NormV {
+				t.Errorf("Wrong norm of v: expect %d, got %d", normV, entry.NormV)
+			}
+			if !entry.Accepted {
+				continue
+			}
+			if accepted >= len(sigs) {
+				t.Errorf("Too many accepted attempts")
+				break
+			}
+			sig := sigs[accepted]
+			if !reflect.DeepEqual(entry.Z1, sig.z1.GetData()) ||
+				!reflect.DeepEqual(entry.Z2, sig.z2.GetData()) ||
+				!reflect.DeepEqual(entry.Indices, sig.c) {
+				t.Errorf("Transcript does not match signature %d", accepted)
+			}
+			for j := range entry.Z1 {
+				z1 := entry.Y1[j] + entry.V1[j]
+				if entry.Bit {
+					z1 = entry.Y1[j] - entry.V1[j]
+				}
+				if z1 != entry.Z1[j] {
+					t.Errorf("z1 != y1 + (-1)^b v1 at %d", j)
+					break
+				}
+			}
+			accepted++
+		}
+		if accepted != len(sigs) {
+			t.Errorf("Expect %d accepted attempts, got %d", len(sigs), accepted)
+		}
+	}
+}
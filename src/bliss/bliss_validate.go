@@ -0,0 +1,204 @@
+package bliss
+
+import (
+	"fmt"
+	"params"
+	"poly"
+)
+
+// Check that a polynomial has exactly Nz1 coefficients of +-1, Nz2
+// coefficients of +-2 and zeros elsewhere, like f and g sampled by
+// poly.UniformPoly.
+func checkSparse(name string, data []int32, param *params.BlissBParam) error {
+	ones, twos := uint32(0), uint32(0)
+	for i := 0; i < len(data); i++ {
+		switch data[i] {
+		case 0:
+		case 1, -1:
+			ones++
+		case 2, -2:
+			twos++
+		default:
+			return fmt.Errorf("Coefficient %d of %s out of range: %w", data[i], name, ErrInvalidKey)
+		}
+	}
+	if ones != param.Nz1 || twos != param.Nz2 {
+		return fmt.Errorf("%s has %d coefficients of +-1 and %d of +-2, expect %d and %d: %w",
+			name, ones, twos, param.Nz1, param.Nz2, ErrInvalidKey)
+	}
+	return nil
+}
+
+// Check that the coefficients of a polynomial are in [0,q).
+func checkModQ(name string, data []int32, q uint32) error {
+	for i := 0; i < len(data); i++ {
+		if data[i] < 0 || uint32(data[i]) >= q {
+			return fmt.Errorf("Coefficient %d of %s out of range [0,%d): %w", data[i], name, q, ErrInvalidKey)
+		}
+	}
+	return nil
+}
+
+// Check that the private key is a BLISS private key: s1 = f and s2 = 2g-1
+// where f and g have exactly Nz1 coefficients of +-1, Nz2 coefficients of +-2
+// and zeros elsewhere, f is invertible, and
+// a = NTT(-s2/s1) with every coefficient in [0,q). The error wraps
+// ErrInvalidKey, or ErrNotInvertible if f is not invertible.
+func (privateKey *BlissPrivateKey) Validate() error {
+	if privateKey == nil || privateKey.s1 == nil || privateKey.s2 == nil || privateKey.a == nil {
+		return fmt.Errorf("Incomplete private key: %w", ErrInvalidKey)
+	}
+	param := privateKey.s1.Param()
+	if privateKey.s2.Param().Version != param.Version || privateKey.a.Param().Version != param.Version {
+		return fmt.Errorf("Mixed BLISS versions in private key: %w", ErrInvalidKey)
+	}
+	if err := checkSparse("f", privateKey.s1.GetData(), param); err != nil {
+		return err
+	}
+	s2data := privateKey.s2.GetData()
+	g := make([]int32, len(s2data))
+	if s2data[0]&1 == 0 {
+		return fmt.Errorf("s2[0] = %d is not odd: %w", s2data[0], ErrInvalidKey)
+	}
+	g[0] = (s2data[0] + 1) / 2
+	for i := 1; i < len(s2data); i++ {
+		if s2data[i]&1 != 0 {
+			return fmt.Errorf("s2[%d] = %d is not even: %w", i, s2data[i], ErrInvalidKey)
+		}
+		g[i] = s2data[i] / 2
+	}
+	if err := checkSparse("g", g, param); err != nil {
+		return err
+	}
+
+	q := int64(param.Q)
+	adata := privateKey.a.GetData()
+	if err := checkModQ("a", adata, param.Q); err != nil {
+		return err
+	}
+	// a = -s2/s1 if and only if NTT(s1)*a + NTT(s2) = 0 element-wise.
+	u, err := privateKey.s1.NTT()
+	if err != nil {
+		return err
+	}
+	t, err := privateKey.s2.NTT()
+	if err != nil {
+		return err
+	}
+	udata := u.GetData()
+	tdata := t.GetData()
+	for i := 0; i < len(adata); i++ {
+		if udata[i] == 0 {
+			return fmt.Errorf("f is %w", ErrNotInvertible)
+		}
+		if (int64(udata[i])*int64(adata[i])+int64(tdata[i]))%q != 0 {
+			return fmt.Errorf("a is not -s2/s1: %w", ErrInvalidKey)
+		}
+	}
+	return nil
+}
+
+// Check that the public key is a BLISS public key, i.e. every coefficient of
+// a (in NTT form) is in [0,q). The error wraps ErrInvalidKey.
+func (publicKey *BlissPublicKey) Validate() error {
+	if publicKey == nil || publicKey.a == nil {
+		return fmt.Errorf("Incomplete public key: %w", ErrInvalidKey)
+	}
+	return checkModQ("a", publicKey.a.GetData(), publicKey.Param().Q)
+}
+
+// Create a private key from the polynomials s1 = f and s2 = 2g-1, e.g. as
+// produced by another implementation of BLISS. The public key is computed
+// from them, and the key is checked by Validate. The polynomials are copied.
+func NewPrivateKeyFromPolys(s1, s2 *poly.PolyArray) (*BlissPrivateKey, error) {
+	if s1 == nil || s2 == nil {
+		return nil, fmt.Errorf("Incomplete private key: %w", ErrInvalidKey)
+	}
+	if s1.Param().Version != s2.Param().Version {
+		return nil, fmt.Errorf("Mixed BLISS versions in private key: %w", ErrInvalidKey)
+	}
+	s1 = s1.ScalarTimes(1)
+	s2 = s2.ScalarTimes(1)
+	if err := checkSparse("f", s1.GetData(), s1.Param()); err != nil {
+		s1.Wipe()
+		s2.Wipe()
+		return nil, err
+	}
+	a, err := publicPoly(s1, s2)
+	if err != nil {
+		s1.Wipe()
+		s2.Wipe()
+		return nil, err
+	}
+	key := &BlissPrivateKey{s1, s2, a, nil, nil, nil}
+	if err := key.Validate(); err != nil {
+		key.Destroy()
+		return nil, err
+	}
+	return key, nil
+}
+
+// Create a public key of the given version from the coefficients of a in NTT
+// form, which is the form stored by Serialize. The coefficients are copied
+// and checked by Validate.
+func NewPublicKeyFromCoeffs(version int, coeffs []int32) (*BlissPublicKey, error) {
+	a, err := poly.New(version)
+	if err != nil {
+		return nil, err
+	}
+	if err := a.SetData(coeffs); err != nil {
+		return nil, fmt.Errorf("%s: %w", err.Error(), ErrInvalidKey)
+	}
+	key := &BlissPublicKey{a}
+	if err := key.Validate(); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// Check the shape of a signature: z1 and z2 of the same version, c of exactly
+// kappa distinct indices in [0,n). A signature failing these checks is
+// malformed rather than rejected, so the error wraps ErrInvalidSignature.
+func (sig *BlissSignature) checkShape(param *params.BlissBParam) error {
+	if sig == nil || sig.z1 == nil || sig.z2 == nil {
+		return fmt.Errorf("Missing signature: %w", ErrInvalidSignature)
+	}
+	if sig.z2.Param().Version != sig.z1.Param().Version {
+		return fmt.Errorf("Mixed BLISS versions in signature: %w", ErrInvalidSignature)
+	}
+	if len(sig.c) != int(param.Kappa) {
+		return fmt.Errorf("Invalid number of indices %d, expect %d: %w",
+			len(sig.c), param.Kappa, ErrInvalidSignature)
+	}
+	seen := make([]bool, param.N)
+	for i := 0; i < len(sig.c); i++ {
+		if sig.c[i] >= param.N {
+			return fmt.Errorf("Invalid index %d, expect < %d: %w",
+				sig.c[i], param.N, ErrInvalidSignature)
+		}
+		if seen[sig.c[i]] {
+			return fmt.Errorf("Repeated index %d: %w", sig.c[i], ErrInvalidSignature)
+		}
+		seen[sig.c[i]] = true
+	}
+	return nil
+}
+
+// Create a signature from the polynomials z1, z2 and the indices c, e.g. as
+// produced by another implementation of BLISS. The signature must have the
+// right shape, and pass the norm checks of the verification algorithm, so
+// that it can be serialized. The arguments are copied.
+func NewSignature(z1, z2 *poly.PolyArray, c []uint32) (*BlissSignature, error) {
+	if z1 == nil || z2 == nil {
+		return nil, fmt.Errorf("Missing signature: %w", ErrInvalidSignature)
+	}
+	param := z1.Param()
+	sig := &BlissSignature{z1.ScalarTimes(1), z2.ScalarTimes(1), append([]uint32{}, c...)}
+	if err := sig.checkShape(param); err != nil {
+		return nil, err
+	}
+	if failure := checkNorms(sig, param); failure != VerifyOK {
+		return nil, failure.Err()
+	}
+	return sig, nil
+}
@@ -0,0 +1,129 @@
+package bliss
+
+import (
+	"errors"
+	"reflect"
+	"sampler"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+			continue
+		}
+		if err := key.Validate(); err != nil {
+			t.Errorf("Generated private key invalid for version %d: %s", i, err.Error())
+		}
+		if err := key.PublicKey().Validate(); err != nil {
+			t.Errorf("Generated public key invalid for version %d: %s", i, err.Error())
+		}
+
+		// Rebuild the keys from their components.
+		tmp, err := NewPrivateKeyFromPolys(key.s1, key.s2)
+		if err != nil {
+			t.Errorf("Error in creating private key: %s", err.Error())
+		} else if !reflect.DeepEqual(key, tmp) {
+			t.Errorf("Different private key created for version %d", i)
+		}
+		pub, err := NewPublicKeyFromCoeffs(i, key.a.GetData())
+		if err != nil {
+			t.Errorf("Error in creating public key: %s", err.Error())
+		} else if !reflect.DeepEqual(key.PublicKey(), pub) {
+			t.Errorf("Different public key created for version %d", i)
+		}
+
+		// Move a coefficient of f, so that f keeps its shape but a is wrong.
+		s1 := key.s1.ScalarTimes(1)
+		data := s1.GetData()
+		j := 0
+		for data[j] == 0 || data[j+1] != 0 {
+			j++
+		}
+		data[j], data[j+1] = 0, data[j]
+		bad := &BlissPrivateKey{s1, key.s2, key.a, nil, nil, nil}
+		if err := bad.Validate(); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Expect ErrInvalidKey for wrong a, got %v", err)
+		}
+		// Too many +-1 in f.
+		data[j] = 1
+		if _, err := NewPrivateKeyFromPolys(s1, key.s2); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Expect ErrInvalidKey for wrong f, got %v", err)
+		}
+		// s2 is not 2g-1.
+		s2 := key.s2.ScalarTimes(1)
+		s2.GetData()[1]++
+		if _, err := NewPrivateKeyFromPolys(key.s1, s2); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Expect ErrInvalidKey for wrong s2, got %v", err)
+		}
+		coeffs := append([]int32{}, key.a.GetData()...)
+		coeffs[0] = int32(key.Param().Q)
+		if _, err := NewPublicKeyFromCoeffs(i, coeffs); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Expect ErrInvalidKey for coefficient q, got %v", err)
+		}
+		if _, err := NewPublicKeyFromCoeffs(i, coeffs[1:]); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("Expect ErrInvalidKey for short coefficients, got %v", err)
+		}
+	}
+}
+
+func TestNewSignature(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		seed := make([]uint8, sampler.SHA_512_DIGEST_LENGTH)
+		for i := 0; i < len(seed); i++ {
+			seed[i] = uint8(i % 8)
+		}
+		entropy, err := sampler.NewEntropy(seed)
+		if err != nil {
+			t.Errorf("Error in initializing entropy: %s", err.Error())
+		}
+
+		key, err := GeneratePrivateKey(i, entropy)
+		if err != nil {
+			t.Errorf("Error in generating private key: %s", err.Error())
+			continue
+		}
+		msg := []byte("Hello world")
+		sig, err := key.Sign(msg, entropy)
+		if err != nil {
+			t.Errorf("Failed to generate signature for version %d: %s", i, err.Error())
+			continue
+		}
+
+		tmp, err := NewSignature(sig.z1, sig.z2, sig.c)
+		if err != nil {
+			t.Errorf("Error in creating signature: %s", err.Error())
+		} else if !reflect.DeepEqual(sig, tmp) {
+			t.Errorf("Different signature created for version %d", i)
+		}
+		err = verified(key.PublicKey().Verify(msg, tmp))
+		if err != nil {
+			t.Errorf("Failed to verify signature for version %d: %s", i, err.Error())
+		}
+
+		if _, err := NewSignature(sig.z1, sig.z2, sig.c[1:]); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("Expect ErrInvalidSignature for short c, got %v", err)
+		}
+		c := append([]uint32{}, sig.c...)
+		c[0] = key.Param().N
+		if _, err := NewSignature(sig.z1, sig.z2, c); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("Expect ErrInvalidSignature for index n, got %v", err)
+		}
+		z1 := sig.z1.ScalarTimes(1)
+		z1.GetData()[0] = int32(key.Param().Binf) + 1
+		if _, err := NewSignature(z1, sig.z2, sig.c); !errors.Is(err, ErrInvalidSignature) {
+			t.Errorf("Expect ErrInvalidSignature for large z1, got %v", err)
+		}
+	}
+}
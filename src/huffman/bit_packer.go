@@ -42,6 +42,8 @@ func NewBitPacker() *BitPacker {
 // Create a new bit unpacker to unpack the given byte array. Since the length
 // of the byte array does not contain enough information for the number of
 // bits, an extra number is passed to specify the total number of bits.
+// Return nil if the byte array holds less than nbit bits, so the caller must
+// check the result before unpacking.
 func NewBitUnpacker(data []byte, nbit uint32) *BitUnpacker {
 	if int(nbit) > len(data)*8 {
 		return nil
@@ -70,3 +70,30 @@ func TestWriteRead(t *testing.T) {
 		}
 	}
 }
+
+func FuzzBitUnpacker(f *testing.F) {
+	f.Add([]byte{0xdc, 0xb8}, uint32(14), uint8(3))
+	f.Add([]byte{}, uint32(1), uint8(1))
+	f.Fuzz(func(t *testing.T, data []byte, nbit uint32, width uint8) {
+		unpacker := NewBitUnpacker(data, nbit)
+		if unpacker == nil {
+			if int(nbit) <= len(data)*8 {
+				t.Fatalf("No unpacker for %d bits in %d bytes", nbit, len(data))
+			}
+			return
+		}
+		w := uint32(width%32) + 1
+		for unpacker.Left() >= w {
+			bits, err := unpacker.ReadBits(w)
+			if err != nil {
+				t.Fatalf("Error in reading bits: %s", err.Error())
+			}
+			if bits >= uint64(1)<<w {
+				t.Fatalf("Read %d from %d bits", bits, w)
+			}
+		}
+		if _, err := unpacker.ReadBits(w); err == nil {
+			t.Fatalf("Read beyond the end")
+		}
+	})
+}
@@ -56,10 +56,19 @@ func NewHuffmanEncoder(code *HuffmanCode) *HuffmanEncoder {
 // Create a HuffmanDecoder for a HuffmanCode and data to decode.
 // The data is in the following format:  | bit-size (2 bytes) | content |.
 // The decoder reads the bit size from the first two bytes, and put the content
-// in a newly created bit unpacker.
-func NewHuffmanDecoder(code *HuffmanCode, data []byte) *HuffmanDecoder {
+// in a newly created bit unpacker. An error is returned if the data is shorter
+// than the bit size.
+func NewHuffmanDecoder(code *HuffmanCode, data []byte) (*HuffmanDecoder, error) {
+	if len(data) < 2 {
+		return nil, fmt.Errorf("Huffman data too short: %d bytes", len(data))
+	}
 	size := uint32(data[0])*256 + uint32(data[1])
-	return &HuffmanDecoder{NewBitUnpacker(data[2:], size), code}
+	unpacker := NewBitUnpacker(data[2:], size)
+	if unpacker == nil {
+		return nil, fmt.Errorf("Huffman data too short: %d bits expected, %d bytes left",
+			size, len(data)-2)
+	}
+	return &HuffmanDecoder{unpacker, code}, nil
 }
 
 // Returns the size of a huffman code, which is the number of symbols this code
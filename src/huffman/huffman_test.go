@@ -92,7 +92,11 @@ func TestHuffmanEncodeDecode(t *testing.T) {
 	// 	fmt.Printf("%02x ", result[i])
 	// }
 	// fmt.Println()
-	decoder := NewHuffmanDecoder(code, result)
+	decoder, err := NewHuffmanDecoder(code, result)
+	if err != nil {
+		t.Errorf("Error in creating decoder: %s", err.Error())
+		return
+	}
 	for i := 0; i < len(data); i++ {
 		next, err := decoder.Next()
 		if err != nil {
@@ -104,3 +108,29 @@ func TestHuffmanEncodeDecode(t *testing.T) {
 		}
 	}
 }
+
+func FuzzHuffmanDecoder(f *testing.F) {
+	code := GetHuffmanTable(1)
+	encoder := NewHuffmanEncoder(code)
+	for i := 0; i < code.Size(); i++ {
+		encoder.Update(i)
+	}
+	f.Add(encoder.Digest())
+	f.Add([]byte{})
+	f.Add([]byte{0xff, 0xff, 0x00})
+	f.Fuzz(func(t *testing.T, data []byte) {
+		decoder, err := NewHuffmanDecoder(code, data)
+		if err != nil {
+			return
+		}
+		for {
+			index, err := decoder.Next()
+			if err != nil {
+				return
+			}
+			if index < 0 || index >= code.Size() {
+				t.Fatalf("Invalid symbol %d, expected < %d", index, code.Size())
+			}
+		}
+	})
+}
@@ -3,6 +3,8 @@ package poly
 
 import (
 	"errors"
+	"fmt"
+	"params"
 )
 
 // The Fast Fourier Transform, which is the core part of NTT.
@@ -69,23 +71,57 @@ func (ma *PolyArray) FFT() (*PolyArray, error) {
 	return array, nil
 }
 
+// The twiddle tables used by NTT and INTT, i.e. {psi^i}_{i=0}^{n-1} and
+// {psi^-i}_{i=0}^{n-1} from the BLISS parameter set, copied into poly arrays.
+// NTT and INTT copy the tables on every call. Prepare the tables once to save
+// the copy when many transforms are done with the same parameter set.
+type NTTTables struct {
+	psi  *PolyArray
+	rpsi *PolyArray
+}
+
+// Copy the twiddle tables of the BLISS parameter set into poly arrays.
+func NewNTTTables(param *params.BlissBParam) (*NTTTables, error) {
+	psi, err := NewPolyArray(param)
+	if err != nil {
+		return nil, err
+	}
+	err = psi.SetData(param.Psi)
+	if err != nil {
+		return nil, err
+	}
+	rpsi, err := NewPolyArray(param)
+	if err != nil {
+		return nil, err
+	}
+	err = rpsi.SetData(param.RPsi)
+	if err != nil {
+		return nil, err
+	}
+	return &NTTTables{psi, rpsi}, nil
+}
+
 // Encapsulate the FFT into the NTT procedure. NTT differentiate from FFT
 // by a preprocessing procedure. In the preprocessing, multiply the i'th
 // element by psi^i, where psi is sqrt(omega) mod q, where omega is a n'th
 // root of unity mod q, which makes psi a 2n'th root of unity.
 // TODO: implement a local version.
 func (p *PolyArray) NTT() (*PolyArray, error) {
-	psi, err := NewPolyArray(p.param)
+	tables, err := NewNTTTables(p.param)
 	if err != nil {
 		return nil, err
 	}
-	// Copy the parameter into a poly array.
-	// p.param.Psi stores the array {psi^i}_{i=0}^{n-1}
-	// TODO: Save this copy procedure by doing the loop explicitly, instead of
-	//       utilizing the TimesModQ method of polyarray.
-	psi.SetData(p.param.Psi)
+	return p.NTTWithTables(tables)
+}
+
+// The NTT procedure with the twiddle tables prepared by the caller.
+func (p *PolyArray) NTTWithTables(tables *NTTTables) (*PolyArray, error) {
 	// Do the multiplication element-wise.
-	f := p.TimesModQ(psi)
+	// tables.psi stores the array {psi^i}_{i=0}^{n-1}
+	f := p.TimesModQ(tables.psi)
+	if f == nil {
+		return nil, fmt.Errorf("Mismatched twiddle tables")
+	}
 	// Apply the FFT.
 	// TODO: Save the intermediate polynomial f by replacing FFT with a
 	//       local version.
@@ -101,24 +137,31 @@ func (p *PolyArray) NTT() (*PolyArray, error) {
 // and IFFT are basically equivalent except for a flip.
 // TODO: Implement a local version.
 func (ntt *PolyArray) INTT() (*PolyArray, error) {
-	// Copy the parameter into a poly array.
-	// p.param.RPsi stores the array {psi^-i}_{i=0}^{n-1}
-	// TODO: Save this copy procedure by doing the loop explicitly, instead of
-	//       utilizing the TimesModQ method of polyarray.
-	rpsi, err := NewPolyArray(ntt.param)
-	rpsi.SetData(ntt.param.RPsi)
+	tables, err := NewNTTTables(ntt.param)
 	if err != nil {
 		return nil, err
 	}
+	return ntt.INTTWithTables(tables)
+}
+
+// The Inversion NTT procedure with the twiddle tables prepared by the caller.
+func (ntt *PolyArray) INTTWithTables(tables *NTTTables) (*PolyArray, error) {
 	f, err := ntt.FFT()
 	if err != nil {
 		return nil, err
 	}
-	f.MulModQ(rpsi)
+	// tables.rpsi stores the array {psi^-i}_{i=0}^{n-1}
+	if f.MulModQ(tables.rpsi) == nil {
+		return nil, fmt.Errorf("Mismatched twiddle tables")
+	}
 	f.flip()
 	return f, nil
 }
 
+// The error returned by InvertAsNTT for a polynomial that has no inverse in
+// Z_q[x]/(x^n+1).
+var ErrNotInvertible = errors.New("PolyArray not invertible")
+
 // Invert a polynomial, assuming that the polynomial is already in NTT form.
 // In NTT form, the polynomial inversion is done by an element-wise inversion
 // mod q. The inversion mod q is equal to taking exponentiation q-2 mod q,
@@ -127,7 +170,7 @@ func (ntt *PolyArray) InvertAsNTT() (*PolyArray, error) {
 	// Check if there is 0 element. If there is, the polynomial is noninvertible.
 	for i := 0; i < int(ntt.n); i++ {
 		if ntt.data[i] == 0 {
-			return nil, errors.New("PolyArray not invertible")
+			return nil, ErrNotInvertible
 		}
 	}
 	// Take the exponentiation q-2.
@@ -138,12 +181,22 @@ func (ntt *PolyArray) InvertAsNTT() (*PolyArray, error) {
 // Multiply a polynomial by another polynomial in NTT form. The result is
 // a copy in polynomial form. The original polynomial remains.
 func (p *PolyArray) MultiplyNTT(ntt *PolyArray) (*PolyArray, error) {
-	lh, err := p.NTT()
+	tables, err := NewNTTTables(p.param)
+	if err != nil {
+		return nil, err
+	}
+	return p.MultiplyNTTWithTables(ntt, tables)
+}
+
+// Multiply a polynomial by another polynomial in NTT form, with the twiddle
+// tables prepared by the caller.
+func (p *PolyArray) MultiplyNTTWithTables(ntt *PolyArray, tables *NTTTables) (*PolyArray, error) {
+	lh, err := p.NTTWithTables(tables)
 	if err != nil {
 		return nil, err
 	}
 	lh.MulModQ(ntt)
-	return lh.INTT()
+	return lh.INTTWithTables(tables)
 }
 
 // The last post-processing procedure in inverse NTT.
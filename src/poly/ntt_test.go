@@ -161,3 +161,38 @@ func TestNTT(t *testing.T) {
 		}
 	}
 }
+
+func TestMultiplyNTTWithTables(t *testing.T) {
+	for i := 0; i <= 4; i++ {
+		lh, err := New(i)
+		if err != nil {
+			t.Errorf("Failed to create polynomial: %s", err.Error())
+		}
+		rh, err := New(i)
+		if err != nil {
+			t.Errorf("Failed to create polynomial: %s", err.Error())
+		}
+		for j := 0; j < int(lh.n); j++ {
+			lh.data[j] = int32(j%7) - 3
+			rh.data[j] = int32((j * 31) % int(rh.q))
+		}
+		tables, err := NewNTTTables(lh.param)
+		if err != nil {
+			t.Errorf("Failed to create tables: %s", err.Error())
+		}
+		expect, err := lh.MultiplyNTT(rh)
+		if err != nil {
+			t.Errorf("Error in MultiplyNTT(): %s", err.Error())
+		}
+		got, err := lh.MultiplyNTTWithTables(rh, tables)
+		if err != nil {
+			t.Errorf("Error in MultiplyNTTWithTables(): %s", err.Error())
+		}
+		for j := 0; j < int(lh.n); j++ {
+			if expect.data[j] != got.data[j] {
+				t.Errorf("Wrong result of MultiplyNTTWithTables(): expect %d, got %d",
+					expect.data[j], got.data[j])
+			}
+		}
+	}
+}
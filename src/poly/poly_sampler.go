@@ -2,6 +2,7 @@
 package poly
 
 import (
+	"fmt"
 	"sampler"
 )
 
@@ -9,11 +10,14 @@ import (
 // coefficients of +-2. d1 and d2 are specified by the BLISS parameter set.
 // The algorithm of this function is taken from the C code version of BLISS
 // implementation https://github.com/SRI-CSL/bliss.
-func UniformPoly(version int, entropy *sampler.Entropy) *PolyArray {
+func UniformPoly(version int, entropy *sampler.Entropy) (*PolyArray, error) {
 	// Create new polynomial by the version number
 	p, err := New(version)
 	if err != nil {
-		return nil
+		return nil, err
+	}
+	if entropy == nil {
+		return nil, fmt.Errorf("Entropy is nil")
 	}
 
 	// Take the parameter n from the polynomial
@@ -41,26 +45,32 @@ func UniformPoly(version int, entropy *sampler.Entropy) *PolyArray {
 		v[j] += (int32((x&1)<<2) - 2) & mask
 	}
 
-	return p
+	return p, nil
 }
 
 // Sample a random polynomial by discrete Gaussian distribution.
 // All the parameters are specified by the BLISS parameter set.
 // The sampler is also created from the verions number.
-func GaussPoly(version int, s *sampler.Sampler) *PolyArray {
+func GaussPoly(version int, s *sampler.Sampler) (*PolyArray, error) {
 	p, err := New(version)
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	n := p.param.N
-	v := make([]int32, n)
+	if s == nil {
+		return nil, fmt.Errorf("Sampler is nil")
+	}
+	GaussPolyTo(p, s)
+	return p, nil
+}
+
+// Sample a random polynomial by discrete Gaussian distribution, and store it
+// in an existing polynomial, so that the storage can be reused.
+func GaussPolyTo(p *PolyArray, s *sampler.Sampler) {
 	// The sampling is done by loop through the array and sampling each
 	// element by one-dimensional discrete Gaussian.
-	for i := 0; i < int(n); i++ {
-		v[i] = s.SampleGauss()
+	for i := 0; i < len(p.data); i++ {
+		p.data[i] = s.SampleGauss()
 	}
-	p.SetData(v)
-	return p
 }
 
 // Split the polynomial sampling procedure into the sum of two Gaussian
@@ -68,18 +78,24 @@ func GaussPoly(version int, s *sampler.Sampler) *PolyArray {
 // at the deviation. The splitted deviations are selected such that
 //        delta_alpha^2 + delta_beta^2 \approx delta^2.
 // This is the alpha version of the splitted sampling.
-func GaussPolyAlpha(version int, s *sampler.Sampler) *PolyArray {
+func GaussPolyAlpha(version int, s *sampler.Sampler) (*PolyArray, error) {
 	p, err := New(version)
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	n := p.param.N
-	v := make([]int32, n)
-	for i := 0; i < int(n); i++ {
-		v[i] = s.SampleGaussCtAlpha()
+	if s == nil {
+		return nil, fmt.Errorf("Sampler is nil")
+	}
+	GaussPolyAlphaTo(p, s)
+	return p, nil
+}
+
+// The alpha version of the splitted sampling, storing the result in an
+// existing polynomial.
+func GaussPolyAlphaTo(p *PolyArray, s *sampler.Sampler) {
+	for i := 0; i < len(p.data); i++ {
+		p.data[i] = s.SampleGaussCtAlpha()
 	}
-	p.SetData(v)
-	return p
 }
 
 // Split the polynomial sampling procedure into the sum of two Gaussian
@@ -87,16 +103,22 @@ func GaussPolyAlpha(version int, s *sampler.Sampler) *PolyArray {
 // at the deviation. The splitted deviations are selected such that
 //        delta_alpha^2 + delta_beta^2 \approx delta^2.
 // This is the beta version of the splitted sampling.
-func GaussPolyBeta(version int, s *sampler.Sampler) *PolyArray {
+func GaussPolyBeta(version int, s *sampler.Sampler) (*PolyArray, error) {
 	p, err := New(version)
 	if err != nil {
-		return nil
+		return nil, err
 	}
-	n := p.param.N
-	v := make([]int32, n)
-	for i := 0; i < int(n); i++ {
-		v[i] = s.SampleGaussCtBeta()
+	if s == nil {
+		return nil, fmt.Errorf("Sampler is nil")
+	}
+	GaussPolyBetaTo(p, s)
+	return p, nil
+}
+
+// The beta version of the splitted sampling, storing the result in an
+// existing polynomial.
+func GaussPolyBetaTo(p *PolyArray, s *sampler.Sampler) {
+	for i := 0; i < len(p.data); i++ {
+		p.data[i] = s.SampleGaussCtBeta()
 	}
-	p.SetData(v)
-	return p
 }
@@ -15,9 +15,10 @@ func TestUniformPoly(t *testing.T) {
 	if err != nil {
 		t.Errorf("Failed to create entropy")
 	}
-	p := UniformPoly(params.BLISS_B_4, entropy)
-	if p == nil {
-		t.Errorf("Failed to generate uniforma polynomial")
+	p, err := UniformPoly(params.BLISS_B_4, entropy)
+	if err != nil {
+		t.Errorf("Failed to generate uniforma polynomial: %s", err.Error())
+		return
 	}
 	count0 := 0
 	count1 := 0
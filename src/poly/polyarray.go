@@ -94,3 +94,27 @@ func (pa *PolyArray) SetData(data []int32) error {
 func (pa *PolyArray) GetData() []int32 {
 	return pa.data
 }
+
+// Overwrite every coefficient with zero, so that a secret polynomial does not
+// stay in memory after use. A nil poly array is ignored.
+func (pa *PolyArray) Wipe() {
+	if pa == nil {
+		return
+	}
+	for i := range pa.data {
+		pa.data[i] = 0
+	}
+}
+
+// Move the coefficients into data and keep them there from now on, wiping
+// the old storage. data must be of length n. This lets the caller keep a
+// secret polynomial in memory it manages, e.g. locked pages.
+func (pa *PolyArray) MoveTo(data []int32) error {
+	if pa.n != uint32(len(data)) {
+		return fmt.Errorf("Mismatched data length!")
+	}
+	copy(data, pa.data)
+	pa.Wipe()
+	pa.data = data
+	return nil
+}
@@ -80,3 +80,32 @@ func TestNew(t *testing.T) {
 		t.Errorf("Failed to create modular polyarray for BLISS_B_4: %s\n", err.Error())
 	}
 }
+
+func TestPolyArrayWipe(t *testing.T) {
+	f, _ := newPolyArray(10, 7)
+	f.SetData([]int32{0, 1, 2, 3, 4, 5, 6, 5, 4, 3})
+	old := f.GetData()
+	data := make([]int32, 10)
+	if err := f.MoveTo(data); err != nil {
+		t.Errorf("Error in moving polyarray: %s", err.Error())
+	}
+	if &f.GetData()[0] != &data[0] || data[3] != 3 {
+		t.Errorf("Polyarray not moved to new storage")
+	}
+	for i := range old {
+		if old[i] != 0 {
+			t.Errorf("Old storage not wiped at pos %d", i)
+		}
+	}
+	if err := f.MoveTo(make([]int32, 9)); err == nil {
+		t.Errorf("Moving to storage of wrong length should fail")
+	}
+	f.Wipe()
+	for i := range data {
+		if data[i] != 0 {
+			t.Errorf("Polyarray not wiped at pos %d", i)
+		}
+	}
+	var g *PolyArray
+	g.Wipe()
+}
@@ -177,3 +177,28 @@ func (entropy *Entropy) Bits(n int) uint32 {
 	}
 	return ret
 }
+
+// Wipe the seed and the pools of random values, so that neither the past
+// nor the future outputs can be recovered from memory. The entropy must not
+// be used afterwards, any sampling from it panics. Destroying it twice is
+// harmless.
+func (entropy *Entropy) Destroy() {
+	for i := range entropy.seed {
+		entropy.seed[i] = 0
+	}
+	for i := range entropy.charpool {
+		entropy.charpool[i] = 0
+	}
+	for i := range entropy.int16pool {
+		entropy.int16pool[i] = 0
+	}
+	for i := range entropy.int64pool {
+		entropy.int64pool[i] = 0
+	}
+	entropy.bitpool = 0
+	entropy.seed = nil
+	entropy.charpool = nil
+	entropy.int16pool = nil
+	entropy.int64pool = nil
+	entropy.bitp, entropy.charp, entropy.int16p, entropy.int64p = 0, 0, 0, 0
+}
@@ -48,3 +48,43 @@ func TestEntropy(t *testing.T) {
 		}
 	}
 }
+
+func TestEntropyDestroy(t *testing.T) {
+	seed := make([]uint8, SHA_512_DIGEST_LENGTH)
+	for i := range seed {
+		seed[i] = uint8(i)
+	}
+	entropy, err := NewEntropy(seed)
+	if err != nil {
+		t.Errorf("Error in initializing entropy: %s", err.Error())
+	}
+	sampler, err := New(0, entropy)
+	if err != nil {
+		t.Errorf("Error in initializing sampler: %s", err.Error())
+	}
+	sampler.SampleGauss()
+	seedp, charpool := entropy.seed, entropy.charpool
+	sampler.Destroy()
+	for i := range seedp {
+		if seedp[i] != 0 {
+			t.Errorf("Seed not wiped at pos %d", i)
+		}
+	}
+	for i := range charpool {
+		if charpool[i] != 0 {
+			t.Errorf("Char pool not wiped at pos %d", i)
+		}
+	}
+	if entropy.seed != nil || sampler.random != nil {
+		t.Errorf("Destroyed entropy still referenced")
+	}
+	entropy.Destroy()
+	sampler.Destroy()
+
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Sampling from destroyed entropy should panic")
+		}
+	}()
+	entropy.Char()
+}
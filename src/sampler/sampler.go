@@ -98,6 +98,25 @@ func New(version int, entropy *Entropy) (*Sampler, error) {
 	return NewSampler(param.Sigma, param.Ell, param.Prec, entropy)
 }
 
+// Create a copy of the sampler that shares the precomputed tables, but takes
+// the randomness from another entropy. The tables are never modified, so the
+// copies can be used from different goroutines.
+func (sampler *Sampler) WithEntropy(entropy *Entropy) *Sampler {
+	ret := *sampler
+	ret.random = entropy
+	return &ret
+}
+
+// Destroy the entropy the sampler takes the randomness from, which is the
+// only secret state of the sampler. The precomputed tables are public and
+// shared, so they are kept. The sampler must not be used afterwards.
+func (sampler *Sampler) Destroy() {
+	if sampler.random != nil {
+		sampler.random.Destroy()
+		sampler.random = nil
+	}
+}
+
 // Sample Bernoulli distribution with probability p.
 // p is stored as a large big-endian integer in an array
 // the real probability is p/2^d, where d is the number of